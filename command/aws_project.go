@@ -49,7 +49,7 @@ var CreateAWSProject = cli.Command{
 			Type:        rtype,
 			Credentials: creds,
 		}
-		client.Project().Create(p)
+		checkError(client.Project().Create(p))
 		color.Green(fmt.Sprintf(h.T("aws.create.success"), p.Name))
 
 		return nil
@@ -75,9 +75,10 @@ var UpdateAWSProject = cli.Command{
 			"secret_access_key": flagDef{typ: "string", mapto: "aws_secret_access_key"},
 		})
 
-		n := client.Project().Get(c.Args()[0])
+		n, err := client.Project().Get(c.Args()[0])
+		checkError(err)
 		n.Credentials = creds
-		client.Project().Update(n)
+		checkError(client.Project().Update(n))
 		color.Green(fmt.Sprintf(h.T("aws.update.success"), n.Name))
 
 		return nil
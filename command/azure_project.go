@@ -50,7 +50,7 @@ var CreateAzureProject = cli.Command{
 			Type:        rtype,
 			Credentials: creds,
 		}
-		client.Project().Create(p)
+		checkError(client.Project().Create(p))
 		color.Green(fmt.Sprintf(h.T("azure.create.success"), p.Name))
 
 		return nil
@@ -82,9 +82,10 @@ var UpdateAzureProject = cli.Command{
 			"environment":     flagDef{typ: "string", mapto: "azure_environment"},
 		})
 
-		n := client.Project().Get(c.Args()[0])
+		n, err := client.Project().Get(c.Args()[0])
+		checkError(err)
 		n.Credentials = creds
-		client.Project().Update(n)
+		checkError(client.Project().Update(n))
 		color.Green(fmt.Sprintf(h.T("azure.update.success"), n.Name))
 
 		return nil
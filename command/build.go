@@ -0,0 +1,119 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package command
+
+import (
+	"fmt"
+	"time"
+
+	h "github.com/ernestio/ernest-cli/helper"
+	"github.com/ernestio/ernest-cli/model"
+	"github.com/ernestio/ernest-cli/view"
+	"github.com/fatih/color"
+	"github.com/urfave/cli"
+)
+
+// TagBuild : Gives a name to a build of an environment
+var TagBuild = cli.Command{
+	Name:        "tag",
+	Usage:       h.T("envs.build.tag.usage"),
+	ArgsUsage:   h.T("envs.build.tag.args"),
+	Description: h.T("envs.build.tag.description"),
+	Flags: []cli.Flag{
+		tStringFlagND("envs.build.tag.flags.message"),
+		tBoolFlag("envs.build.tag.flags.force"),
+	},
+	Action: func(c *cli.Context) error {
+		paramsLenValidation(c, 4, "envs.build.tag.args")
+		project := c.Args()[0]
+		env := c.Args()[1]
+		name := c.Args()[3]
+		if err := model.ValidateTagName(name); err != nil {
+			h.PrintErrorCode(err.Error(), h.ExitUsage)
+		}
+		client := esetup(c, AuthUsersValidation)
+
+		build, err := client.Build().Resolve(project, env, c.Args()[2])
+		checkError(err)
+
+		cfg := client.Config()
+		if tag, ok := cfg.FindTag(project, env, name); ok && tag.Build != build.ID && !c.Bool("force") {
+			h.PrintError(fmt.Sprintf(h.T("envs.build.tag.exists"), name, tag.Build))
+		}
+
+		cfg.AddTag(project, env, model.BuildTag{
+			Name:      name,
+			Build:     build.ID,
+			Message:   c.String("message"),
+			User:      cfg.User,
+			CreatedAt: time.Now().UTC().Format(time.RFC3339),
+		})
+		if err := model.SaveConfig(cfg); err != nil {
+			h.PrintError(err.Error())
+		}
+
+		color.Green(fmt.Sprintf(h.T("envs.build.tag.success"), build.ID, name))
+		return nil
+	},
+}
+
+// ListBuildTags : Lists the tags given to the builds of an environment
+var ListBuildTags = cli.Command{
+	Name:        "tags",
+	Usage:       h.T("envs.build.tags.usage"),
+	ArgsUsage:   h.T("envs.build.tags.args"),
+	Description: h.T("envs.build.tags.description"),
+	Action: func(c *cli.Context) error {
+		paramsLenValidation(c, 2, "envs.build.tags.args")
+		setupGlobals(c)
+		cfg := getConfig()
+		if cfg == nil {
+			h.PrintError("Environment not configured, please use target command")
+		}
+
+		view.PrintBuildTags(cfg.Tags(c.Args()[0], c.Args()[1]))
+		return nil
+	},
+}
+
+// UntagBuild : Removes a tag from the builds of an environment
+var UntagBuild = cli.Command{
+	Name:        "untag",
+	Usage:       h.T("envs.build.untag.usage"),
+	ArgsUsage:   h.T("envs.build.untag.args"),
+	Description: h.T("envs.build.untag.description"),
+	Action: func(c *cli.Context) error {
+		paramsLenValidation(c, 3, "envs.build.untag.args")
+		setupGlobals(c)
+		cfg := getConfig()
+		if cfg == nil {
+			h.PrintError("Environment not configured, please use target command")
+		}
+
+		project := c.Args()[0]
+		env := c.Args()[1]
+		name := c.Args()[2]
+		if !cfg.RemoveTag(project, env, name) {
+			h.PrintErrorCode(fmt.Sprintf(h.T("envs.build.untag.not_found"), name), h.ExitNotFound)
+		}
+		if err := model.SaveConfig(cfg); err != nil {
+			h.PrintError(err.Error())
+		}
+
+		color.Green(fmt.Sprintf(h.T("envs.build.untag.success"), name))
+		return nil
+	},
+}
+
+// BuildEnv : Build related subcommands
+var BuildEnv = cli.Command{
+	Name:  "build",
+	Usage: "Environment build related subcommands",
+	Subcommands: []cli.Command{
+		TagBuild,
+		ListBuildTags,
+		UntagBuild,
+	},
+}
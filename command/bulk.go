@@ -0,0 +1,332 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package command
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	h "github.com/ernestio/ernest-cli/helper"
+	"github.com/ernestio/ernest-cli/manager"
+	"github.com/ernestio/ernest-cli/model"
+	"github.com/ernestio/ernest-cli/view"
+	"github.com/urfave/cli"
+
+	emodels "github.com/ernestio/ernest-go-sdk/models"
+)
+
+// FilterFlags : flags filtering the environments a command runs on
+var FilterFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "project",
+		Usage: "Run on the environments of the projects matching a glob pattern",
+	},
+	cli.StringFlag{
+		Name:  "status",
+		Usage: "Run on the environments with a status, as errored or done",
+	},
+}
+
+// SelectorFlags : flags selecting the environments a command runs on, and
+// how many of them are processed at the same time
+var SelectorFlags = append(FilterFlags,
+	cli.IntFlag{
+		Name:  "workers",
+		Value: 4,
+		Usage: "Number of environments processed at the same time",
+	},
+)
+
+// bulkOperation : runs an operation on an environment, given by its
+// project and short name, errors mark it as failed on the summary
+type bulkOperation func(project, env string) (model.BulkResult, error)
+
+// isBulk : checks if an environment command has been given a selector
+// instead of a single environment
+func isBulk(c *cli.Context) bool {
+	if c.String("project") != "" || c.String("status") != "" {
+		return true
+	}
+	args := c.Args()
+	return len(args) == 2 && (model.IsGlob(args[0]) || model.IsGlob(args[1]))
+}
+
+// envSelector : gets the selector given to an environment command, as
+// flags and an optional environment name pattern
+func envSelector(c *cli.Context) model.EnvSelector {
+	sel := model.EnvSelector{
+		Project: c.String("project"),
+		Status:  c.String("status"),
+	}
+
+	switch args := c.Args(); len(args) {
+	case 0:
+	case 1:
+		sel.Name = args[0]
+	case 2:
+		sel.Project, sel.Name = args[0], args[1]
+	default:
+		h.PrintErrorCode("Please provide a single environment name pattern", h.ExitUsage)
+	}
+
+	if err := sel.Validate(); err != nil {
+		h.PrintErrorCode(err.Error(), h.ExitUsage)
+	}
+
+	return sel
+}
+
+// envRef : gets the project and short name of a listed environment
+func envRef(env *emodels.Environment) (string, string) {
+	return model.SplitEnvName(env.Project, env.Name)
+}
+
+// selectEnvs : lists all environments matching a selector
+func selectEnvs(client *manager.Client, sel model.EnvSelector) []*emodels.Environment {
+	all, err := client.Environment().ListAll()
+	checkError(err)
+
+	envs := make([]*emodels.Environment, 0)
+	for _, env := range all {
+		if project, name := envRef(env); sel.Match(project, name, env.Status) {
+			envs = append(envs, env)
+		}
+	}
+	if len(envs) == 0 {
+		h.PrintErrorCode("No environments match the given selector", h.ExitNotFound)
+	}
+
+	return envs
+}
+
+// runBulk : runs an operation on many environments with a bounded number
+// of workers, printing a summary and exiting with a failure code if it
+// failed on any of them
+func runBulk(c *cli.Context, envs []*emodels.Environment, op bulkOperation) {
+	results := make([]model.BulkResult, len(envs))
+	forEachEnv(c.Int("workers"), envs, func(i int) {
+		project, name := envRef(envs[i])
+		r, err := op(project, name)
+		if err != nil {
+			r = model.BulkResult{Result: model.BulkFailed, Message: err.Error()}
+		}
+		r.Project = project
+		r.Environment = name
+		results[i] = r
+	})
+
+	printBulkResults(results)
+}
+
+// forEachEnv : calls fn with the index of every environment, running up
+// to the given number of workers at the same time
+func forEachEnv(workers int, envs []*emodels.Environment, fn func(i int)) {
+	forEach(workers, len(envs), fn)
+}
+
+// forEach : calls fn with every index up to n, running up to the given
+// number of workers at the same time
+func forEach(workers, n int, fn func(i int)) {
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > n {
+		workers = n
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+func printBulkResults(results []model.BulkResult) {
+	view.PrintBulkResults(results)
+
+	code := h.ExitSuccess
+	for _, r := range results {
+		switch r.Result {
+		case model.BulkFailed:
+			code = h.ExitFailure
+		case model.BulkAwaiting:
+			if code == h.ExitSuccess {
+				code = h.ExitAwaiting
+			}
+		}
+	}
+	if code != h.ExitSuccess {
+		h.Exit(code)
+	}
+}
+
+// confirmBulk : lists the selected environments and asks for confirmation
+func confirmBulk(envs []*emodels.Environment, question string) bool {
+	names := make([]string, 0, len(envs))
+	for _, env := range envs {
+		project, name := envRef(env)
+		names = append(names, "  "+project+"/"+name)
+	}
+	fmt.Println(strings.Join(names, "\n"))
+	fmt.Printf(question, len(envs))
+	return askForConfirmation()
+}
+
+// waitDeadline : gets the time the --timeout flag expires at, zero to
+// wait forever
+func waitDeadline(c *cli.Context) time.Time {
+	if timeout := c.Duration("timeout"); timeout > 0 {
+		return time.Now().Add(timeout)
+	}
+	return time.Time{}
+}
+
+// waitBuildStatus : polls a build until it finishes, returning its status.
+// It fails if the build is still running when the deadline expires, a zero
+// deadline waits forever
+func waitBuildStatus(client *manager.Client, project, env, id string, expires time.Time) (string, error) {
+	for {
+		build, err := client.Build().Get(project, env, id)
+		if err != nil {
+			return "", err
+		}
+		if _, ok := waitExitCodes[build.Status]; ok {
+			return build.Status, nil
+		}
+
+		wait := waitInterval
+		if !expires.IsZero() {
+			remaining := time.Until(expires)
+			if remaining <= 0 {
+				return "", fmt.Errorf("Timed out waiting for build %s, its status is %s", build.ID, build.Status)
+			}
+			if remaining < wait {
+				wait = remaining
+			}
+		}
+		time.Sleep(wait)
+	}
+}
+
+// bulkResult : creates the result of an operation on an environment
+func bulkResult(result, message string) model.BulkResult {
+	return model.BulkResult{Result: result, Message: message}
+}
+
+// bulkSync : syncs the selected environments, waiting for their results
+func bulkSync(c *cli.Context) {
+	sel := envSelector(c)
+	client := esetup(c, AuthUsersValidation)
+	envs := selectEnvs(client, sel)
+	expires := waitDeadline(c)
+
+	runBulk(c, envs, func(project, env string) (model.BulkResult, error) {
+		action, err := client.Environment().Sync(project, env)
+		if err != nil {
+			return model.BulkResult{}, err
+		}
+		if action.ResourceID == "" {
+			return bulkResult(model.BulkOK, "No sync required"), nil
+		}
+
+		status, err := waitBuildStatus(client, project, env, action.ResourceID, expires)
+		if err != nil {
+			return model.BulkResult{}, err
+		}
+		switch status {
+		case "awaiting_resolution":
+			return bulkResult(model.BulkAwaiting, "Changes detected"), nil
+		case "errored":
+			return bulkResult(model.BulkFailed, "Sync failed"), nil
+		}
+		return bulkResult(model.BulkOK, "No changes detected"), nil
+	})
+}
+
+// bulkValidate : validates the selected environments against their
+// attached policies
+func bulkValidate(c *cli.Context) {
+	sel := envSelector(c)
+	client := esetup(c, AuthUsersValidation)
+	envs := selectEnvs(client, sel)
+
+	runBulk(c, envs, func(project, env string) (model.BulkResult, error) {
+		validation, err := client.Environment().Validate(project, env)
+		if err != nil {
+			return model.BulkResult{}, err
+		}
+		if validation == nil {
+			return bulkResult(model.BulkOK, "No policies attached"), nil
+		}
+
+		passed, failed, total := validation.Stats()
+		if failed > 0 {
+			return bulkResult(model.BulkFailed, fmt.Sprintf("%d of %d controls failed", failed, total)), nil
+		}
+		return bulkResult(model.BulkOK, fmt.Sprintf("%d of %d controls passed", passed, total)), nil
+	})
+}
+
+// bulkReset : resets the selected environments
+func bulkReset(c *cli.Context) {
+	sel := envSelector(c)
+	client := esetup(c, AuthUsersValidation)
+	envs := selectEnvs(client, sel)
+
+	runBulk(c, envs, func(project, env string) (model.BulkResult, error) {
+		if _, err := client.Environment().Reset(project, env); err != nil {
+			return model.BulkResult{}, err
+		}
+		return bulkResult(model.BulkOK, "Environment reset"), nil
+	})
+}
+
+// bulkDelete : destroys the selected environments, waiting for their
+// destroy builds to finish
+func bulkDelete(c *cli.Context) {
+	sel := envSelector(c)
+	client := esetup(c, AuthUsersValidation)
+	envs := selectEnvs(client, sel)
+
+	if !c.Bool("yes") && !confirmBulk(envs, h.T("envs.destroy.bulk_confirmation")) {
+		return
+	}
+	expires := waitDeadline(c)
+
+	runBulk(c, envs, func(project, env string) (model.BulkResult, error) {
+		if c.Bool("force") {
+			if _, err := client.Environment().ForceDeletion(project, env); err != nil {
+				return model.BulkResult{}, err
+			}
+			return bulkResult(model.BulkOK, "Environment removed"), nil
+		}
+
+		build, err := client.Environment().Delete(project, env)
+		if err != nil {
+			return model.BulkResult{}, err
+		}
+		status, err := waitBuildStatus(client, project, env, build.ID, expires)
+		if err != nil {
+			return model.BulkResult{}, err
+		}
+		if status == "errored" {
+			return bulkResult(model.BulkFailed, "Destroy build finished with errors"), nil
+		}
+		return bulkResult(model.BulkOK, "Environment removed"), nil
+	})
+}
@@ -9,11 +9,14 @@ import (
 	"fmt"
 	"io/ioutil"
 	"log"
+	"os"
 	"strings"
+	"time"
 
 	h "github.com/ernestio/ernest-cli/helper"
 	"github.com/ernestio/ernest-cli/manager"
 	"github.com/ernestio/ernest-cli/model"
+	"github.com/ernestio/ernest-cli/view"
 	"github.com/urfave/cli"
 	yaml "gopkg.in/yaml.v2"
 
@@ -41,19 +44,128 @@ type validation func(*manager.Client)
 var validations = map[string]validation{
 	NonEmptuTokenVal: func(client *manager.Client) {
 		if client.Config().Token == "" {
-			h.PrintError("You're not allowed to perform this action, please log in")
+			h.PrintErrorCode("You're not allowed to perform this action, please log in", h.ExitUnauthorized)
 		}
+		checkTokenExpiry(client)
 	},
 	NonAdminVal: func(client *manager.Client) {
-		session := client.Session().Get()
+		session, err := client.Session().Get()
+		checkError(err)
 		if !session.IsAdmin() {
-			h.PrintError("You don’t have permissions to perform this action")
+			h.PrintErrorCode("You don’t have permissions to perform this action", h.ExitUnauthorized)
 		}
 	},
 }
 
 var session *emodels.Session
 
+// tokenExpiryWarning : time left on the session token from which commands
+// warn about its expiry
+const tokenExpiryWarning = time.Hour
+
+// checkTokenExpiry : warns when the session token is about to expire, and
+// renews it once it has expired if credentials are available
+func checkTokenExpiry(client *manager.Client) {
+	expiry, ok := client.Config().TokenExpiry()
+	if !ok {
+		return
+	}
+
+	left := time.Until(expiry)
+	switch {
+	case left <= 0:
+		if client.Renew() != nil {
+			h.PrintErrorCode(h.T("login.session.expired"), h.ExitUnauthorized)
+		}
+	case left < tokenExpiryWarning:
+		fmt.Fprintf(os.Stderr, h.T("login.session.expiring")+"\n", left.Round(time.Minute))
+	}
+}
+
+// OutputFlag : selects the output format for list and info commands
+var OutputFlag = cli.StringFlag{
+	Name:  "output, o",
+	Value: "table",
+	Usage: "Output format: table, json, yaml or template=<go template>",
+}
+
+// ProgressFlag : selects how the progress of a monitored build is shown
+var ProgressFlag = cli.StringFlag{
+	Name:  "progress",
+	Usage: "Build progress output: tty, plain or json, plain when not running on a terminal",
+}
+
+// TimeoutFlag : maximum time to wait for a monitored build to finish
+var TimeoutFlag = cli.DurationFlag{
+	Name:  "timeout",
+	Usage: "Maximum time to wait for the build to finish, as 30s, 10m or 1h. Waits forever by default",
+}
+
+// ProfileFlag : selects the target profile to use instead of the current one
+var ProfileFlag = cli.StringFlag{
+	Name:  "profile",
+	Usage: "Target profile to use, it can also be set with ERNEST_PROFILE",
+}
+
+// GlobalFlags : flags accepted by the app and every command
+var GlobalFlags = []cli.Flag{
+	OutputFlag,
+	ProfileFlag,
+}
+
+// WithGlobalFlags : adds the global flags to a list of commands and their
+// subcommands, so they can be provided after the command name. Commands
+// defining their own flag with the same name keep it
+func WithGlobalFlags(cmds []cli.Command) []cli.Command {
+	for i := range cmds {
+		for _, flag := range GlobalFlags {
+			if !hasFlagName(cmds[i].Flags, flag) {
+				cmds[i].Flags = append(cmds[i].Flags, flag)
+			}
+		}
+		cmds[i].Subcommands = WithGlobalFlags(cmds[i].Subcommands)
+	}
+	return cmds
+}
+
+func hasFlagName(flags []cli.Flag, flag cli.Flag) bool {
+	name := strings.Split(flag.GetName(), ",")[0]
+	for _, f := range flags {
+		if strings.Split(f.GetName(), ",")[0] == name {
+			return true
+		}
+	}
+	return false
+}
+
+// globalString : gets the value of a global flag from the closest context
+// it has been set on
+func globalString(c *cli.Context, flag cli.StringFlag) string {
+	name := strings.Split(flag.GetName(), ",")[0]
+	for ctx := c; ctx != nil; ctx = ctx.Parent() {
+		flags := ctx.Command.Flags
+		if ctx.Command.Name == "" && ctx.App != nil {
+			flags = ctx.App.Flags
+		}
+		if !definesFlag(flags, flag) {
+			continue
+		}
+		if ctx.IsSet(name) {
+			return ctx.String(name)
+		}
+	}
+	return ""
+}
+
+func definesFlag(flags []cli.Flag, flag cli.Flag) bool {
+	for _, f := range flags {
+		if f.GetName() == flag.GetName() {
+			return true
+		}
+	}
+	return false
+}
+
 //Esetup : sets up connection to ernest
 func Esetup(c *cli.Context, vals []string) *manager.Client {
 	return esetup(c, vals)
@@ -62,7 +174,11 @@ func Esetup(c *cli.Context, vals []string) *manager.Client {
 // esetup ...
 func esetup(c *cli.Context, vals []string) *manager.Client {
 	session = nil
-	config := model.GetConfig()
+	if c != nil {
+		setupGlobals(c)
+	}
+
+	config := getConfig()
 	if config == nil {
 		config = &model.Config{}
 		if c != nil {
@@ -83,6 +199,24 @@ func esetup(c *cli.Context, vals []string) *manager.Client {
 
 }
 
+// setupGlobals : applies the global flags
+func setupGlobals(c *cli.Context) {
+	h.EvaluateError(view.SetOutput(globalString(c, OutputFlag)))
+	if profile := globalString(c, ProfileFlag); profile != "" {
+		model.ActiveProfile = profile
+	}
+}
+
+// getConfig : gets the config of the active profile, failing when the
+// profile selected with --profile or ERNEST_PROFILE does not exist
+func getConfig() *model.Config {
+	config, err := model.GetConfig()
+	if err != nil {
+		h.PrintErrorCode(err.Error(), h.ExitUsage)
+	}
+	return config
+}
+
 // Elogin : logs in
 func Elogin(usr, pwd, vc string) *manager.Client {
 	return elogin(usr, pwd, vc)
@@ -91,7 +225,7 @@ func Elogin(usr, pwd, vc string) *manager.Client {
 // elogin ...
 func elogin(usr, pwd, vc string) *manager.Client {
 	session = nil
-	config := model.GetConfig()
+	config := getConfig()
 	if config == nil {
 		config = &model.Config{}
 	}
@@ -111,7 +245,7 @@ func stringWithDefault(c *cli.Context, key, def string) (val string) {
 
 func paramsLenValidation(c *cli.Context, number int, translationKey string) {
 	if len(c.Args()) < number {
-		h.PrintError("Please provide required parameters:\n" + h.T(translationKey))
+		h.PrintErrorCode("Please provide required parameters:\n"+h.T(translationKey), h.ExitUsage)
 	}
 }
 
@@ -123,7 +257,7 @@ func requiredFlags(c *cli.Context, flags []string) {
 		}
 	}
 	if len(errs) > 0 {
-		h.PrintError(strings.Join(errs, "\n"))
+		h.PrintErrorCode(strings.Join(errs, "\n"), h.ExitUsage)
 	}
 }
 
@@ -157,6 +291,13 @@ func tIntFlag(key string) cli.IntFlag {
 	return intFlag(h.T(key+".alias"), h.T(key+".desc"))
 }
 
+func tStringSliceFlag(key string) cli.StringSliceFlag {
+	return cli.StringSliceFlag{
+		Name:  h.T(key + ".alias"),
+		Usage: h.T(key + ".desc"),
+	}
+}
+
 func stringFlag(name, value, usage string) cli.StringFlag {
 	return cli.StringFlag{
 		Name:  name,
@@ -197,8 +338,8 @@ func parseTemplateFlags(c *cli.Context, keys map[string]flagDef) map[string]inte
 			flags[mapto] = t.def
 		}
 		if t.typ == "string" {
-			if c.String(k) != "" {
-				flags[mapto] = c.String(k)
+			if v := flagString(c, k); v != "" {
+				flags[mapto] = v
 			}
 		} else {
 			if c.Bool(k) != false {
@@ -217,7 +358,7 @@ func parseTemplateFlags(c *cli.Context, keys map[string]flagDef) map[string]inte
 		for _, e := range errs {
 			msgs = append(msgs, "  - "+e)
 		}
-		h.PrintError(strings.Join(msgs, "\n"))
+		h.PrintErrorCode(strings.Join(msgs, "\n"), h.ExitUsage)
 	}
 
 	return flags
@@ -228,14 +369,29 @@ func mapDefinition(c *cli.Context) *model.Definition {
 	if len(c.Args()) == 1 {
 		file = c.Args()[0]
 	}
+	return loadDefinition(c, file)
+}
+
+// loadDefinition : loads a definition file, resolving its includes,
+// variables and referenced files
+func loadDefinition(c *cli.Context, file string) *model.Definition {
 	payload, err := ioutil.ReadFile(file)
 	if err != nil {
 		h.PrintError("You should specify a valid template path or store an ernest.yml on the current folder")
 	}
-	def := model.Definition{}
+	def := model.Definition{
+		Path:    file,
+		Confine: c.Bool("confine-imports"),
+	}
 	if err := def.Load(payload); err != nil {
 		h.PrintError("Could not process definition yaml")
 	}
+	if err := def.LoadIncludes(); err != nil {
+		h.PrintErrorCode(err.Error(), h.ExitValidation)
+	}
+	if err := def.Interpolate(mapVariables(c)); err != nil {
+		h.PrintErrorCode(err.Error(), h.ExitValidation)
+	}
 	if err := def.LoadFileImports(); err != nil {
 		h.PrintError(err.Error())
 	}
@@ -243,6 +399,28 @@ func mapDefinition(c *cli.Context) *model.Definition {
 	return &def
 }
 
+// parseEnvRef : splits an environment reference given as project/env,
+// optionally followed by @build
+func parseEnvRef(ref string) (project, env, build string) {
+	if i := strings.LastIndex(ref, "@"); i >= 0 {
+		ref, build = ref[:i], ref[i+1:]
+	}
+	parts := strings.Split(ref, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		h.PrintErrorCode("Invalid environment '"+ref+"', it should be specified as project/environment", h.ExitUsage)
+	}
+	return parts[0], parts[1], build
+}
+
+// mapVariables : loads the variables given with --var-file and --var flags
+func mapVariables(c *cli.Context) model.Variables {
+	vars, err := model.LoadVariables(c.StringSlice("var-file"), c.StringSlice("var"))
+	if err != nil {
+		h.PrintErrorCode(err.Error(), h.ExitUsage)
+	}
+	return vars
+}
+
 func getProjectTemplateAsMap(template string) (map[string]interface{}, error) {
 	flags := make(map[string]interface{}, 0)
 	payload, err := ioutil.ReadFile(template)
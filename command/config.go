@@ -0,0 +1,91 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package command
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+
+	h "github.com/ernestio/ernest-cli/helper"
+	"github.com/ernestio/ernest-cli/model"
+	"github.com/fatih/color"
+	"github.com/howeyc/gopass"
+	"github.com/urfave/cli"
+)
+
+func init() {
+	model.SecretsPassphrase = askSecretsPassphrase
+}
+
+// askSecretsPassphrase : prompts for the passphrase of the encrypted
+// secrets file, twice when it is being created
+func askSecretsPassphrase(create bool) ([]byte, error) {
+	fmt.Fprint(os.Stderr, "Secrets passphrase: ")
+	pass, err := gopass.GetPasswdMasked()
+	if err != nil || !create {
+		return pass, err
+	}
+
+	fmt.Fprint(os.Stderr, "Confirm secrets passphrase: ")
+	confirm, err := gopass.GetPasswdMasked()
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(pass, confirm) {
+		return nil, errors.New("Passphrases don't match")
+	}
+
+	return pass, nil
+}
+
+// MigrateSecrets command
+// Moves the tokens of the configured profiles to another secret store
+var MigrateSecrets = cli.Command{
+	Name:        "migrate-secrets",
+	Usage:       h.T("config.migrate_secrets.usage"),
+	ArgsUsage:   h.T("config.migrate_secrets.args"),
+	Description: h.T("config.migrate_secrets.description"),
+	Flags: []cli.Flag{
+		tStringFlagND("config.migrate_secrets.flags.store"),
+		tBoolFlag("config.migrate_secrets.flags.all"),
+	},
+	Action: func(c *cli.Context) error {
+		requiredFlags(c, []string{"store"})
+		setupGlobals(c)
+
+		store := c.String("store")
+		if _, err := model.GetSecretStore(store); err != nil {
+			h.PrintErrorCode(err.Error(), h.ExitUsage)
+		}
+
+		p := model.GetProfiles()
+		if p == nil {
+			h.PrintError("Environment not configured, please use target command")
+		}
+		names := []string{p.Active()}
+		if c.Bool("all") {
+			names = p.Names()
+		}
+
+		h.EvaluateError(p.MigrateSecrets(names, store))
+
+		for _, name := range names {
+			color.Green(fmt.Sprintf(h.T("config.migrate_secrets.success"), name, store))
+		}
+
+		return nil
+	},
+}
+
+// CmdConfig : Local configuration subcommands
+var CmdConfig = cli.Command{
+	Name:  "config",
+	Usage: "Local configuration related subcommands",
+	Subcommands: []cli.Command{
+		MigrateSecrets,
+	},
+}
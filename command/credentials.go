@@ -0,0 +1,171 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package command
+
+import (
+	"fmt"
+	"os"
+
+	h "github.com/ernestio/ernest-cli/helper"
+	"github.com/ernestio/ernest-cli/manager"
+	"github.com/ernestio/ernest-cli/model"
+	"github.com/ernestio/ernest-cli/view"
+	"github.com/fatih/color"
+	"github.com/urfave/cli"
+)
+
+// AddCredentials command
+// Stores a named set of provider credentials on the profile in use
+var AddCredentials = cli.Command{
+	Name:        "add",
+	Usage:       h.T("credentials.add.usage"),
+	ArgsUsage:   h.T("credentials.add.args"),
+	Description: h.T("credentials.add.description"),
+	Flags: append([]cli.Flag{
+		tStringFlagND("credentials.add.flags.provider"),
+		tBoolFlag("credentials.add.flags.force"),
+	}, AllProviderFlags...),
+	Action: func(c *cli.Context) error {
+		paramsLenValidation(c, 1, "credentials.add.args")
+		requiredFlags(c, []string{"provider"})
+		cfg := credentialsConfig(c)
+
+		set := &model.CredentialSet{
+			Name:     c.Args()[0],
+			Provider: c.String("provider"),
+			Values:   ProviderFlagsToSlice(c),
+		}
+		if len(set.Values) == 0 {
+			h.PrintErrorCode(h.T("credentials.add.errors.empty"), h.ExitUsage)
+		}
+		if err := set.Validate(""); err != nil {
+			h.PrintErrorCode(err.Error(), h.ExitValidation)
+		}
+		if _, ok := cfg.CredentialSets[set.Name]; ok && !c.Bool("force") {
+			h.PrintError(fmt.Sprintf(h.T("credentials.add.errors.exists"), set.Name))
+		}
+
+		h.EvaluateError(cfg.SetCredentialSet(set))
+		h.EvaluateError(model.SaveConfig(cfg))
+
+		color.Green(fmt.Sprintf(h.T("credentials.add.success"), set.Name, cfg.Secrets()))
+		return nil
+	},
+}
+
+// ListCredentials command
+// Lists the credential sets of the profile in use
+var ListCredentials = cli.Command{
+	Name:        "list",
+	Usage:       h.T("credentials.list.usage"),
+	ArgsUsage:   h.T("credentials.list.args"),
+	Description: h.T("credentials.list.description"),
+	Action: func(c *cli.Context) error {
+		cfg := credentialsConfig(c)
+		view.PrintCredentialSets(cfg.CredentialSets)
+
+		return nil
+	},
+}
+
+// DeleteCredentials command
+// Removes a credential set from the profile in use
+var DeleteCredentials = cli.Command{
+	Name:        "delete",
+	Usage:       h.T("credentials.delete.usage"),
+	ArgsUsage:   h.T("credentials.delete.args"),
+	Description: h.T("credentials.delete.description"),
+	Action: func(c *cli.Context) error {
+		paramsLenValidation(c, 1, "credentials.delete.args")
+		cfg := credentialsConfig(c)
+
+		name := c.Args()[0]
+		err := cfg.RemoveCredentialSet(name)
+		if err == model.ErrSecretNotFound {
+			h.PrintErrorCode(fmt.Sprintf(h.T("credentials.errors.not_found"), name), h.ExitNotFound)
+		}
+		h.EvaluateError(err)
+		h.EvaluateError(model.SaveConfig(cfg))
+
+		color.Green(fmt.Sprintf(h.T("credentials.delete.success"), name))
+		return nil
+	},
+}
+
+// credentialsConfig : gets the profile credential sets are kept on
+func credentialsConfig(c *cli.Context) *model.Config {
+	setupGlobals(c)
+	cfg := getConfig()
+	if cfg == nil {
+		h.PrintError("Environment not configured, please use target command")
+	}
+	return cfg
+}
+
+// envCredentials : gets the credentials given to an environment, from the
+// credential set named with --credentials, overridden by the provider
+// flags. The set is validated against the provider of the project
+func envCredentials(c *cli.Context, client *manager.Client, project string) map[string]interface{} {
+	flags := ProviderFlagsToSlice(c)
+	name := c.String("credentials")
+	if name == "" {
+		return flags
+	}
+
+	set, err := credentialSet(client.Config(), name)
+	switch {
+	case err == model.ErrSecretNotFound:
+		h.PrintErrorCode(fmt.Sprintf(h.T("credentials.errors.not_found"), name), h.ExitNotFound)
+	case err != nil:
+		h.PrintError(err.Error())
+	}
+	// credentials files can't be checked, as they have no provider
+	if set.Provider != "" {
+		p, err := client.Project().Get(project)
+		checkError(err)
+		if err := set.Validate(p.Type); err != nil {
+			h.PrintErrorCode(err.Error(), h.ExitValidation)
+		}
+	}
+
+	creds := make(map[string]interface{})
+	for k, v := range set.Values {
+		creds[k] = v
+	}
+	for k, v := range flags {
+		creds[k] = v
+	}
+
+	return creds
+}
+
+// credentialSet : gets a credential set of the profile, names not found
+// on it being taken as credentials files given by previous versions
+func credentialSet(cfg *model.Config, name string) (*model.CredentialSet, error) {
+	set, err := cfg.CredentialSet(name)
+	if err != model.ErrSecretNotFound {
+		return set, err
+	}
+	if _, serr := os.Stat(name); serr != nil {
+		return nil, err
+	}
+
+	values, err := getProjectTemplateAsMap(name)
+	if err != nil {
+		return nil, err
+	}
+	return &model.CredentialSet{Name: name, Values: values}, nil
+}
+
+// CmdCredentials : Credential sets related subcommands
+var CmdCredentials = cli.Command{
+	Name:  "credentials",
+	Usage: "Local credential sets related subcommands",
+	Subcommands: []cli.Command{
+		AddCredentials,
+		ListCredentials,
+		DeleteCredentials,
+	},
+}
@@ -0,0 +1,97 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package command
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ernestio/ernest-cli/model"
+)
+
+func TestCredentialSet(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ernest-credentials")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	legacy := filepath.Join(dir, "aws.yml")
+	invalid := filepath.Join(dir, "invalid.yml")
+	stored := filepath.Join(dir, "prod")
+	files := map[string]string{
+		legacy:  "aws_access_key_id: AKIA\naws_secret_access_key: secret\n",
+		invalid: "aws_access_key_id: [AKIA\n",
+		stored:  "aws_access_key_id: FROM-FILE\n",
+	}
+	for path, content := range files {
+		if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cfg := &model.Config{Name: "default", CredentialSets: map[string]*model.CredentialSet{
+		"prod": {Provider: "aws", Values: map[string]interface{}{"aws_access_key_id": "FROM-SET"}},
+		stored: {Provider: "aws", Values: map[string]interface{}{"aws_access_key_id": "FROM-SET"}},
+	}}
+
+	tests := []struct {
+		name     string
+		ref      string
+		provider string
+		values   map[string]interface{}
+		err      string
+	}{
+		{
+			name:     "stored sets",
+			ref:      "prod",
+			provider: "aws",
+			values:   map[string]interface{}{"aws_access_key_id": "FROM-SET"},
+		},
+		{
+			name:     "stored sets take precedence over files",
+			ref:      stored,
+			provider: "aws",
+			values:   map[string]interface{}{"aws_access_key_id": "FROM-SET"},
+		},
+		{
+			name:   "unknown names are loaded as legacy files",
+			ref:    legacy,
+			values: map[string]interface{}{"aws_access_key_id": "AKIA", "aws_secret_access_key": "secret"},
+		},
+		{
+			name: "unknown names which are not files are not found",
+			ref:  filepath.Join(dir, "missing.yml"),
+			err:  model.ErrSecretNotFound.Error(),
+		},
+		{
+			name: "invalid legacy files fail",
+			ref:  invalid,
+			err:  "is not valid yaml file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set, err := credentialSet(cfg, tt.ref)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("expected error %q, got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if set.Provider != tt.provider || !reflect.DeepEqual(set.Values, tt.values) {
+				t.Errorf("got %s credentials %v, want %s credentials %v", set.Provider, set.Values, tt.provider, tt.values)
+			}
+		})
+	}
+}
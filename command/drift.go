@@ -0,0 +1,153 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package command
+
+import (
+	"os"
+	"time"
+
+	h "github.com/ernestio/ernest-cli/helper"
+	"github.com/ernestio/ernest-cli/manager"
+	"github.com/ernestio/ernest-cli/model"
+	"github.com/ernestio/ernest-cli/view"
+	"github.com/r3labs/diff"
+	"github.com/urfave/cli"
+
+	emodels "github.com/ernestio/ernest-go-sdk/models"
+)
+
+// DriftReport command
+// Reports the environments whose infrastructure drifted from their
+// definitions
+var DriftReport = cli.Command{
+	Name:        "report",
+	Usage:       h.T("drift.report.usage"),
+	ArgsUsage:   h.T("drift.report.args"),
+	Description: h.T("drift.report.description"),
+	Flags: append([]cli.Flag{
+		tBoolFlag("drift.report.flags.sync"),
+		tStringFlagND("drift.report.flags.junit"),
+		TimeoutFlag,
+	}, SelectorFlags...),
+	Action: func(c *cli.Context) error {
+		sel := envSelector(c)
+		client := esetup(c, AuthUsersValidation)
+		envs := selectEnvs(client, sel)
+		expires := waitDeadline(c)
+
+		reports := make([]model.DriftReport, len(envs))
+		forEachEnv(c.Int("workers"), envs, func(i int) {
+			reports[i] = driftReport(client, envs[i], c.Bool("sync"), expires)
+		})
+
+		switch junit := c.String("junit"); junit {
+		case "":
+			view.PrintDriftReport(reports)
+		case "-":
+			h.EvaluateError(view.WriteDriftJUnit(os.Stdout, reports))
+		default:
+			f, err := os.Create(junit)
+			h.EvaluateError(err)
+			h.EvaluateError(view.WriteDriftJUnit(f, reports))
+			h.EvaluateError(f.Close())
+			view.PrintDriftReport(reports)
+		}
+
+		code := h.ExitSuccess
+		for _, r := range reports {
+			if r.Error != "" {
+				code = h.ExitFailure
+			} else if r.Drifted && code == h.ExitSuccess {
+				code = h.ExitAwaiting
+			}
+		}
+		if code != h.ExitSuccess {
+			h.Exit(code)
+		}
+
+		return nil
+	},
+}
+
+// driftReport : checks the drift on an environment, syncing it first if
+// requested or reading the result of its last sync otherwise. Syncs must
+// finish before the given deadline
+func driftReport(client *manager.Client, env *emodels.Environment, sync bool, expires time.Time) model.DriftReport {
+	project, name := envRef(env)
+	r := model.DriftReport{
+		Project:     project,
+		Environment: name,
+		Status:      env.Status,
+		Components:  []string{},
+	}
+
+	if sync {
+		action, err := client.Environment().Sync(project, name)
+		if err != nil {
+			r.Error = err.Error()
+			return r
+		}
+		if action.ResourceID != "" {
+			r.Status, err = waitBuildStatus(client, project, name, action.ResourceID, expires)
+			if err != nil {
+				r.Error = err.Error()
+				return r
+			}
+			if r.Status == "errored" {
+				r.Error = "Sync failed"
+				return r
+			}
+		}
+	}
+
+	if r.Status != "awaiting_resolution" {
+		return r
+	}
+
+	r.Drifted = true
+	build, changelog, err := driftChangelog(client, project, name)
+	if err != nil {
+		r.Error = err.Error()
+		return r
+	}
+	r.Build = build
+	r.Components = model.DriftedComponents(changelog)
+
+	return r
+}
+
+// driftChangelog : gets the changes detected by the latest build of an
+// environment, compared to the previous one
+func driftChangelog(client *manager.Client, project, env string) (string, diff.Changelog, error) {
+	builds, err := client.Build().List(project, env)
+	if err != nil {
+		return "", nil, err
+	}
+	latest, err := manager.ResolveBuild(builds, manager.BuildLatest)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var changelog *diff.Changelog
+	if previous, perr := manager.ResolveBuild(builds, "latest~1"); perr == nil {
+		changelog, err = client.Build().Diff(project, env, previous.ID, latest.ID)
+	} else {
+		changelog, err = client.Build().Changelog(project, env, latest.ID)
+	}
+	if err != nil || changelog == nil {
+		return latest.ID, nil, err
+	}
+
+	return latest.ID, *changelog, nil
+}
+
+// CmdDrift : Drift related subcommands
+var CmdDrift = cli.Command{
+	Name:  "drift",
+	Usage: "Drift related subcommands",
+	Subcommands: []cli.Command{
+		DriftReport,
+	},
+}
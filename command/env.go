@@ -6,14 +6,13 @@ package command
 
 // CmdProject subcommand
 import (
-	"encoding/json"
 	"fmt"
-	"os"
-	"strconv"
 	"strings"
 	"time"
 
 	h "github.com/ernestio/ernest-cli/helper"
+	"github.com/ernestio/ernest-cli/manager"
+	"github.com/ernestio/ernest-cli/model"
 	"github.com/ernestio/ernest-cli/view"
 	"github.com/fatih/color"
 	"github.com/urfave/cli"
@@ -29,7 +28,8 @@ var ListEnvs = cli.Command{
 	Description: h.T("envs.list.description"),
 	Action: func(c *cli.Context) error {
 		client := esetup(c, AuthUsersValidation)
-		envs := client.Environment().ListAll()
+		envs, err := client.Environment().ListAll()
+		checkError(err)
 		view.PrintEnvList(envs)
 
 		return nil
@@ -44,15 +44,17 @@ var UpdateEnv = cli.Command{
 	ArgsUsage:   h.T("envs.update.args"),
 	Description: h.T("envs.update.description"),
 	Flags: append([]cli.Flag{
+		tStringFlagND("envs.update.flags.credentials"),
 		tStringFlagND("envs.update.flags.submissions"),
 	}, AllProviderFlags...),
 	Action: func(c *cli.Context) error {
 		paramsLenValidation(c, 2, "envs.update.args")
 		client := esetup(c, AuthUsersValidation)
-		env := client.Environment().Get(c.Args()[0], c.Args()[1])
-		env.Credentials = ProviderFlagsToSlice(c)
+		env, err := client.Environment().Get(c.Args()[0], c.Args()[1])
+		checkError(err)
+		env.Credentials = envCredentials(c, client, env.Project)
 		env.Options = MapEnvOptions(c, env.Options)
-		client.Environment().Update(env)
+		checkError(client.Environment().Update(env))
 		color.Green("Environment successfully updated")
 
 		return nil
@@ -77,10 +79,10 @@ var CreateEnv = cli.Command{
 		env := emodels.Environment{
 			Name:        c.Args()[1],
 			Project:     c.Args()[0],
-			Credentials: ProviderFlagsToSlice(c),
+			Credentials: envCredentials(c, client, c.Args()[0]),
 			Options:     MapEnvOptions(c, nil),
 		}
-		client.Environment().Create(c.Args()[0], &env)
+		checkError(client.Environment().Create(c.Args()[0], &env))
 		color.Green("Environment successfully created")
 
 		return nil
@@ -99,44 +101,57 @@ var ApplyEnv = cli.Command{
 		tBoolFlag("envs.apply.flags.dry"),
 		tBoolFlag("envs.apply.flags.verbose"),
 		tStringFlagND("envs.apply.flags.credentials"),
+		tStringSliceFlag("envs.apply.flags.var"),
+		tStringSliceFlag("envs.apply.flags.var-file"),
+		tBoolFlag("envs.apply.flags.confine-imports"),
+		ProgressFlag,
+		TimeoutFlag,
 	}, AllProviderFlags...),
 	Action: func(c *cli.Context) error {
 		paramsLenValidation(c, 1, "envs.apply.args")
 		client := esetup(c, AuthUsersValidation)
 		def := mapDefinition(c)
+		if c.Bool("verbose") && len(def.Imports) > 0 {
+			fmt.Println("Referenced files:")
+			for _, path := range def.Imports {
+				fmt.Println("  " + path)
+			}
+		}
 
-		if _, err := client.Cli().Environments.Get(def.Project, def.Name); err != nil {
+		if _, err := client.Environment().Get(def.Project, def.Name); manager.IsNotFound(err) {
 			env := emodels.Environment{
 				Name:        def.Name,
 				Project:     def.Project,
-				Credentials: ProviderFlagsToSlice(c),
+				Credentials: envCredentials(c, client, def.Project),
 				Options:     MapEnvOptions(c, nil),
 			}
-			client.Environment().Create(def.Project, &env)
+			checkError(client.Environment().Create(def.Project, &env))
+		} else {
+			checkError(err)
 		}
 		payload, err := def.Save()
 		if err != nil {
 			h.PrintError("Could not finalize definition yaml")
 		}
 		if c.Bool("dry") == true {
-			view.EnvDry(*client.Build().Dry(payload))
+			dry, err := client.Build().Dry(payload)
+			checkError(err)
+			view.EnvDry(*dry)
 			return nil
 		}
+
+		build, err := client.Build().Create(payload)
+		checkError(err)
 		if c.Bool("verbose") {
-			client.Build().Verbose = true
+			view.PrintValidation(build.Validation)
 		}
-
-		build := client.Build().Create(payload)
 		if build.Status == "submitted" {
 			color.Green("Build has been succesfully submitted and is awaiting approval.")
-			os.Exit(0)
+			h.Exit(h.ExitAwaiting)
 		}
 
-		h.Monitorize(client.Build().Stream(build.ID))
-		view.PrintEnvInfo(
-			client.Environment().Get(def.Project, def.Name),
-			client.Build().Get(def.Project, def.Name, build.GetID()),
-		)
+		monitorBuild(c, client, def.Project, def.Name, build.ID)
+		printBuildInfo(c, client, def.Project, def.Name, build.GetID())
 
 		return nil
 	},
@@ -150,44 +165,31 @@ var SyncEnv = cli.Command{
 	Usage:       h.T("envs.sync.usage"),
 	ArgsUsage:   h.T("envs.sync.args"),
 	Description: h.T("envs.sync.description"),
+	Flags:       append([]cli.Flag{ProgressFlag, TimeoutFlag}, SelectorFlags...),
 	Action: func(c *cli.Context) error {
+		if isBulk(c) {
+			bulkSync(c)
+			return nil
+		}
 		paramsLenValidation(c, 2, "envs.sync.args")
 		client := esetup(c, AuthUsersValidation)
 
 		project := c.Args()[0]
 		env := c.Args()[1]
 
-		action := client.Environment().Sync(project, env)
+		action, err := client.Environment().Sync(project, env)
+		checkError(err)
 		if action.ResourceID == "" {
 			return nil
 		}
 
-		stream := client.Build().Stream(action.ResourceID)
-		for {
-			var m map[string]interface{}
-
-			msg, ok := <-stream
-			if !ok {
-				h.PrintError("could not monitor sync progress")
-			}
-
-			if msg == nil {
-				continue
-			}
-
-			if json.Unmarshal(msg, &m) != nil {
-				h.PrintError("could not parse response")
-			}
-
-			if m["_subject"].(string) == "build.import.done" {
-				break
-			}
-		}
+		monitorBuild(c, client, project, env, action.ResourceID)
 
 		// wait for definition mapper to update build graph
 		time.Sleep(time.Second)
 
-		build := client.Build().Get(project, env, action.ResourceID)
+		build, err := client.Build().Get(project, env, action.ResourceID)
+		checkError(err)
 
 		switch build.Status {
 		case "done":
@@ -196,12 +198,16 @@ var SyncEnv = cli.Command{
 			color.Red("Changes detected")
 			fmt.Println("")
 
-			builds := client.Build().List(project, env)
+			builds, err := client.Build().List(project, env)
+			checkError(err)
 
-			b1 := buildIDFromIndex(builds, strconv.Itoa(len(builds)-1))
-			b2 := buildIDFromIndex(builds, strconv.Itoa(len(builds)))
+			b1, err := manager.ResolveBuild(builds, "latest~1")
+			checkError(err)
+			b2, err := manager.ResolveBuild(builds, manager.BuildLatest)
+			checkError(err)
 
-			changelog := client.Build().Diff(project, env, b1.ID, b2.ID)
+			changelog, err := client.Build().Diff(project, env, b1.ID, b2.ID)
+			checkError(err)
 			if len(*changelog) == 0 {
 				color.Green("There are no changes detected")
 				return nil
@@ -210,23 +216,15 @@ var SyncEnv = cli.Command{
 			color.Red("If rejected, ernest will action the following changes:")
 
 			view.PrintDiff(changelog)
+			h.Exit(h.ExitAwaiting)
 		case "errored":
-			color.Red("Sync failed!")
+			h.PrintErrorCode("Sync failed!", h.ExitBuildErrored)
 		}
 
 		return nil
 	},
 }
 
-func buildIDFromIndex(builds []*emodels.Build, index string) *emodels.Build {
-	num, _ := strconv.Atoi(index)
-	if num < 1 || num > len(builds) {
-		h.PrintError("Invalid build ID")
-	}
-	num = len(builds) - num
-	return builds[num]
-}
-
 // ReviewEnv command
 // Approval for outstanding build submissions
 var ReviewEnv = cli.Command{
@@ -238,6 +236,8 @@ var ReviewEnv = cli.Command{
 	Flags: append([]cli.Flag{
 		tBoolFlag("envs.review.flags.accept"),
 		tBoolFlag("envs.review.flags.reject"),
+		ProgressFlag,
+		TimeoutFlag,
 	}),
 	Action: func(c *cli.Context) error {
 		paramsLenValidation(c, 2, "envs.review.args")
@@ -255,22 +255,23 @@ var ReviewEnv = cli.Command{
 		}
 
 		if resolution == "" {
-			builds := client.Build().List(project, env)
-			b2 := buildIDFromIndex(builds, strconv.Itoa(len(builds)))
+			builds, err := client.Build().List(project, env)
+			checkError(err)
+			b2, err := manager.ResolveBuild(builds, manager.BuildLatest)
+			checkError(err)
 
-			changelog := client.Build().Changelog(project, env, b2.ID)
+			changelog, err := client.Build().Changelog(project, env, b2.ID)
+			checkError(err)
 			view.PrintDiff(changelog)
 
 			return nil
 		}
 
-		action := client.Environment().Review(project, env, resolution)
+		action, err := client.Environment().Review(project, env, resolution)
+		checkError(err)
 		if action.ResourceID != "" {
-			h.Monitorize(client.Build().Stream(action.ResourceID))
-			view.PrintEnvInfo(
-				client.Environment().Get(project, env),
-				client.Build().Get(project, env, action.ResourceID),
-			)
+			monitorBuild(c, client, project, env, action.ResourceID)
+			printBuildInfo(c, client, project, env, action.ResourceID)
 		}
 
 		return nil
@@ -289,6 +290,8 @@ var ResolveEnv = cli.Command{
 		tBoolFlag("envs.resolve.flags.accept"),
 		tBoolFlag("envs.resolve.flags.reject"),
 		tBoolFlag("envs.resolve.flags.ignore"),
+		ProgressFlag,
+		TimeoutFlag,
 	}),
 	Action: func(c *cli.Context) error {
 		paramsLenValidation(c, 2, "envs.resolve.args")
@@ -309,12 +312,16 @@ var ResolveEnv = cli.Command{
 		}
 
 		if resolution == "" {
-			builds := client.Build().List(project, env)
+			builds, err := client.Build().List(project, env)
+			checkError(err)
 
-			b1 := buildIDFromIndex(builds, strconv.Itoa(len(builds)-1))
-			b2 := buildIDFromIndex(builds, strconv.Itoa(len(builds)))
+			b1, err := manager.ResolveBuild(builds, "latest~1")
+			checkError(err)
+			b2, err := manager.ResolveBuild(builds, manager.BuildLatest)
+			checkError(err)
 
-			changelog := client.Build().Diff(project, env, b1.ID, b2.ID)
+			changelog, err := client.Build().Diff(project, env, b1.ID, b2.ID)
+			checkError(err)
 			view.PrintDiff(changelog)
 			fmt.Printf("\n\n")
 			view.PrintValidation(b2.Validation)
@@ -322,9 +329,10 @@ var ResolveEnv = cli.Command{
 			return nil
 		}
 
-		action := client.Environment().Resolve(c.Args()[0], c.Args()[1], resolution)
+		action, err := client.Environment().Resolve(c.Args()[0], c.Args()[1], resolution)
+		checkError(err)
 		if action.ResourceID != "" {
-			h.Monitorize(client.Build().Stream(action.ResourceID))
+			monitorBuild(c, client, c.Args()[0], c.Args()[1], action.ResourceID)
 		}
 
 		return nil
@@ -338,14 +346,20 @@ var ValidateEnv = cli.Command{
 	Usage:       h.T("envs.validate.usage"),
 	ArgsUsage:   h.T("envs.validate.args"),
 	Description: h.T("envs.validate.description"),
+	Flags:       SelectorFlags,
 	Action: func(c *cli.Context) error {
+		if isBulk(c) {
+			bulkValidate(c)
+			return nil
+		}
 		paramsLenValidation(c, 2, "envs.validate.args")
 		client := esetup(c, AuthUsersValidation)
 
 		project := c.Args()[0]
 		env := c.Args()[1]
 
-		validation := client.Environment().Validate(project, env)
+		validation, err := client.Environment().Validate(project, env)
+		checkError(err)
 
 		view.PrintValidation(validation)
 
@@ -360,16 +374,23 @@ var DestroyEnv = cli.Command{
 	Usage:       h.T("envs.destroy.usage"),
 	ArgsUsage:   h.T("envs.destroy.args"),
 	Description: h.T("envs.destroy.description"),
-	Flags: []cli.Flag{
+	Flags: append([]cli.Flag{
 		tBoolFlag("envs.destroy.flags.force"),
 		tBoolFlag("envs.destroy.flags.yesflag"),
-	},
+		ProgressFlag,
+		TimeoutFlag,
+	}, SelectorFlags...),
 	Action: func(c *cli.Context) error {
+		if isBulk(c) {
+			bulkDelete(c)
+			return nil
+		}
 		paramsLenValidation(c, 2, "envs.destroy.args")
 		client := esetup(c, AuthUsersValidation)
 
 		if c.Bool("force") {
-			client.Environment().ForceDeletion(c.Args()[0], c.Args()[1])
+			_, err := client.Environment().ForceDeletion(c.Args()[0], c.Args()[1])
+			checkError(err)
 		} else {
 			if c.Bool("yes") == false {
 				fmt.Print(h.T("envs.destroy.confirmation"))
@@ -377,10 +398,13 @@ var DestroyEnv = cli.Command{
 					return nil
 				}
 			}
-			build := client.Environment().Delete(c.Args()[0], c.Args()[1])
-			h.Monitorize(client.Build().Stream(build.ID))
+			build, err := client.Environment().Delete(c.Args()[0], c.Args()[1])
+			checkError(err)
+			monitorBuild(c, client, c.Args()[0], c.Args()[1], build.ID)
+		}
+		if progressMode(c) != h.ProgressJSON {
+			color.Green(h.T("envs.destroy.success"))
 		}
-		color.Green(h.T("envs.destroy.success"))
 		return nil
 	},
 }
@@ -392,11 +416,22 @@ var HistoryEnv = cli.Command{
 	Usage:       h.T("envs.history.usage"),
 	ArgsUsage:   h.T("envs.history.args"),
 	Description: h.T("envs.history.description"),
+	Flags: []cli.Flag{
+		tIntFlag("envs.history.flags.limit"),
+		tStringFlagND("envs.history.flags.since"),
+		tStringFlagND("envs.history.flags.until"),
+		tStringFlagND("envs.history.flags.status"),
+		tStringFlagND("envs.history.flags.type"),
+		tStringFlagND("envs.history.flags.user"),
+		tBoolFlag("envs.history.flags.changes"),
+	},
 	Action: func(c *cli.Context) error {
 		paramsLenValidation(c, 2, "envs.history.args")
+		filter := mapHistoryFilter(c)
 		client := esetup(c, AuthUsersValidation)
-		envs := client.Build().List(c.Args()[0], c.Args()[1])
-		view.PrintEnvHistory(c.Args()[1], envs)
+		builds, err := client.Build().List(c.Args()[0], c.Args()[1])
+		checkError(err)
+		view.PrintEnvHistory(c.Args()[1], historyEntries(client, c.Args()[0], c.Args()[1], builds, filter), filter.changes)
 		return nil
 	},
 }
@@ -407,10 +442,16 @@ var ResetEnv = cli.Command{
 	Usage:       h.T("envs.reset.usage"),
 	ArgsUsage:   h.T("envs.reset.args"),
 	Description: h.T("envs.reset.description"),
+	Flags:       SelectorFlags,
 	Action: func(c *cli.Context) error {
+		if isBulk(c) {
+			bulkReset(c)
+			return nil
+		}
 		paramsLenValidation(c, 2, "envs.reset.args")
 		client := esetup(c, AuthUsersValidation)
-		client.Environment().Reset(c.Args()[0], c.Args()[1])
+		_, err := client.Environment().Reset(c.Args()[0], c.Args()[1])
+		checkError(err)
 		color.Red(fmt.Sprintf(h.T("envs.reset.success"), c.Args()[0], c.Args()[1]))
 		return nil
 	},
@@ -424,44 +465,36 @@ var RevertEnv = cli.Command{
 	Description: h.T("envs.revert.description"),
 	Flags: []cli.Flag{
 		tBoolFlag("envs.revert.flags.dry"),
+		ProgressFlag,
+		TimeoutFlag,
 	},
 	Action: func(c *cli.Context) error {
 		paramsLenValidation(c, 3, "envs.revert.args")
 		client := esetup(c, AuthUsersValidation)
-		builds := client.Build().List(c.Args()[0], c.Args()[1])
-		build := getBuildByPosition(c.Args()[2], builds)
-		def := client.Build().Definition(c.Args()[0], c.Args()[1], build.ID)
+		build, err := client.Build().Resolve(c.Args()[0], c.Args()[1], c.Args()[2])
+		checkError(err)
+		def, err := client.Build().Definition(c.Args()[0], c.Args()[1], build.ID)
+		checkError(err)
 
 		if c.Bool("dry") == true {
-			view.EnvDry(*client.Build().Dry([]byte(def)))
+			dry, err := client.Build().Dry([]byte(def))
+			checkError(err)
+			view.EnvDry(*dry)
 		} else {
-			build := client.Build().Create([]byte(def))
+			build, err := client.Build().Create([]byte(def))
+			checkError(err)
 			if build.Status == "submitted" {
 				color.Green(h.T("envs.revert.success"))
-				os.Exit(0)
+				h.Exit(h.ExitAwaiting)
 			}
 
-			h.Monitorize(client.Build().Stream(build.ID))
+			monitorBuild(c, client, c.Args()[0], c.Args()[1], build.ID)
 		}
 
 		return nil
 	},
 }
 
-func getBuildByPosition(input interface{}, builds []*emodels.Build) *emodels.Build {
-	pos, _ := input.(string)
-	position, err := strconv.Atoi(pos)
-	if err != nil {
-		position = len(builds)
-	}
-	position = len(builds) - position
-
-	if position < 0 || position > len(builds)-1 {
-		h.PrintError("Specified environment build does not exist")
-	}
-	return builds[position]
-}
-
 // DefinitionEnv command
 // Shows the current definition of an environment by its name
 var DefinitionEnv = cli.Command{
@@ -480,9 +513,11 @@ var DefinitionEnv = cli.Command{
 		})
 		client := esetup(c, AuthUsersValidation)
 
-		builds := client.Build().List(c.Args()[0], c.Args()[1])
-		build := getBuildByPosition(flags["build"], builds)
-		def := client.Build().Definition(c.Args()[0], c.Args()[1], build.ID)
+		ref, _ := flags["build"].(string)
+		build, err := client.Build().Resolve(c.Args()[0], c.Args()[1], ref)
+		checkError(err)
+		def, err := client.Build().Definition(c.Args()[0], c.Args()[1], build.ID)
+		checkError(err)
 
 		fmt.Println(def)
 
@@ -504,31 +539,155 @@ var InfoEnv = cli.Command{
 		paramsLenValidation(c, 2, "envs.info.args")
 		client := esetup(c, AuthUsersValidation)
 
-		build := client.Build().BuildByPosition(c.Args()[0], c.Args()[1], c.String("build"))
-		build = client.Build().Get(c.Args()[0], c.Args()[1], build.ID)
-		env := client.Environment().Get(c.Args()[0], c.Args()[1])
+		build, err := client.Build().Resolve(c.Args()[0], c.Args()[1], c.String("build"))
+		checkError(err)
+		build, err = client.Build().Get(c.Args()[0], c.Args()[1], build.ID)
+		checkError(err)
+		env, err := client.Environment().Get(c.Args()[0], c.Args()[1])
+		checkError(err)
 		view.PrintEnvInfo(env, build)
 
 		return nil
 	},
 }
 
-// DiffEnv : Shows detailed information of an env by its name
+// DiffEnv : Shows the differences between two builds, two environments or
+// a local definition and a build
 var DiffEnv = cli.Command{
 	Name:        "diff",
 	Aliases:     []string{"i"},
 	Usage:       h.T("envs.diff.usage"),
 	ArgsUsage:   h.T("envs.diff.args"),
 	Description: h.T("envs.diff.description"),
+	Flags: []cli.Flag{
+		tStringFlagND("envs.diff.flags.from"),
+		tStringFlagND("envs.diff.flags.to"),
+		tStringFlagND("envs.diff.flags.file"),
+		tStringSliceFlag("envs.diff.flags.var"),
+		tStringSliceFlag("envs.diff.flags.var-file"),
+		tBoolFlag("envs.diff.flags.confine-imports"),
+	},
+	Action: func(c *cli.Context) error {
+		if c.String("from") == "" && c.String("to") == "" && c.String("file") == "" {
+			paramsLenValidation(c, 4, "envs.diff.args")
+			client := esetup(c, AuthUsersValidation)
+
+			build1, err := client.Build().Resolve(c.Args()[0], c.Args()[1], c.Args()[2])
+			checkError(err)
+			build2, err := client.Build().Resolve(c.Args()[0], c.Args()[1], c.Args()[3])
+			checkError(err)
+			changelog, err := client.Build().Diff(c.Args()[0], c.Args()[1], build1.GetID(), build2.GetID())
+			checkError(err)
+
+			view.PrintDiff(changelog)
+
+			return nil
+		}
+
+		if c.String("file") != "" && c.String("to") != "" {
+			h.PrintErrorCode("--file is compared with the --from environment, it can't be used with --to", h.ExitUsage)
+		}
+		if c.String("file") == "" && (c.String("from") == "" || c.String("to") == "") {
+			h.PrintErrorCode("Please provide both --from and --to environments, or a --file", h.ExitUsage)
+		}
+
+		var fromProject, fromEnv, fromBuild string
+		if c.String("from") != "" {
+			fromProject, fromEnv, fromBuild = parseEnvRef(c.String("from"))
+		}
+
+		var toProject, toEnv string
+		var to []byte
+		if file := c.String("file"); file != "" {
+			def := loadDefinition(c, file)
+			payload, err := def.Save()
+			if err != nil {
+				h.PrintError("Could not finalize definition yaml")
+			}
+			to = payload
+			toProject, toEnv = def.Project, def.Name
+			if fromProject == "" {
+				fromProject, fromEnv = def.Project, def.Name
+			}
+		}
+
+		client := esetup(c, AuthUsersValidation)
+		from := buildDefinition(client, fromProject, fromEnv, fromBuild)
+		if to == nil {
+			var toBuild string
+			toProject, toEnv, toBuild = parseEnvRef(c.String("to"))
+			to = buildDefinition(client, toProject, toEnv, toBuild)
+		}
+
+		// environments always differ on their identity, which is not
+		// relevant when comparing their definitions
+		var ignore []string
+		if fromProject != toProject || fromEnv != toEnv {
+			ignore = []string{"name", "project"}
+		}
+
+		changelog, err := model.DiffDefinitions(from, to, ignore...)
+		if err != nil {
+			h.PrintError(err.Error())
+		}
+
+		if len(changelog) == 0 && view.IsTable() {
+			color.Green("There are no changes detected")
+			return nil
+		}
+		view.PrintDiff(&changelog)
+
+		return nil
+	},
+}
+
+// buildDefinition : gets the definition of a build of an environment
+func buildDefinition(client *manager.Client, project, env, ref string) []byte {
+	build, err := client.Build().Resolve(project, env, ref)
+	checkError(err)
+	def, err := client.Build().Definition(project, env, build.ID)
+	checkError(err)
+	return []byte(def)
+}
+
+// PlanEnv : Shows the changes a definition would apply to an env
+var PlanEnv = cli.Command{
+	Name:        "plan",
+	Usage:       h.T("envs.plan.usage"),
+	ArgsUsage:   h.T("envs.plan.args"),
+	Description: h.T("envs.plan.description"),
+	Flags: []cli.Flag{
+		tStringSliceFlag("envs.plan.flags.var"),
+		tStringSliceFlag("envs.plan.flags.var-file"),
+		tBoolFlag("envs.plan.flags.confine-imports"),
+	},
 	Action: func(c *cli.Context) error {
-		paramsLenValidation(c, 4, "envs.diff.args")
 		client := esetup(c, AuthUsersValidation)
+		def := mapDefinition(c)
+		payload, err := def.Save()
+		if err != nil {
+			h.PrintError("Could not finalize definition yaml")
+		}
+
+		var current []byte
+		build, err := client.Build().Resolve(def.Project, def.Name, "")
+		if !manager.IsNotFound(err) {
+			checkError(err)
+			d, err := client.Build().Definition(def.Project, def.Name, build.ID)
+			checkError(err)
+			current = []byte(d)
+		}
 
-		build1 := client.Build().BuildByPosition(c.Args()[0], c.Args()[1], c.Args()[2])
-		build2 := client.Build().BuildByPosition(c.Args()[0], c.Args()[1], c.Args()[3])
-		changelog := client.Build().Diff(c.Args()[0], c.Args()[1], build1.GetID(), build2.GetID())
+		changelog, err := model.DiffDefinitions(current, payload)
+		if err != nil {
+			h.PrintError(err.Error())
+		}
 
-		view.PrintDiff(changelog)
+		if len(changelog) == 0 && view.IsTable() {
+			color.Green("No changes, the environment is up to date with the definition.")
+			return nil
+		}
+		view.PrintDiff(&changelog)
 
 		return nil
 	},
@@ -544,6 +703,8 @@ var ImportEnv = cli.Command{
 	Flags: []cli.Flag{
 		tStringFlag("envs.import.flags.project"),
 		tStringFlag("envs.import.flags.filters"),
+		ProgressFlag,
+		TimeoutFlag,
 	},
 	Action: func(c *cli.Context) error {
 		paramsLenValidation(c, 2, "envs.import.args")
@@ -558,9 +719,10 @@ var ImportEnv = cli.Command{
 			Name:    c.Args()[1],
 			Project: c.Args()[0],
 		}
-		client.Environment().Create(c.Args()[0], &env)
-		a := client.Environment().Import(c.Args()[0], c.Args()[1], filters)
-		h.Monitorize(client.Build().Stream(a.ResourceID))
+		checkError(client.Environment().Create(c.Args()[0], &env))
+		a, err := client.Environment().Import(c.Args()[0], c.Args()[1], filters)
+		checkError(err)
+		monitorBuild(c, client, c.Args()[0], c.Args()[1], a.ResourceID)
 
 		return nil
 	},
@@ -600,11 +762,17 @@ var CmdEnv = cli.Command{
 		InfoEnv,
 		MonitorEnv,
 		DiffEnv,
+		PlanEnv,
 		ImportEnv,
 		SyncEnv,
 		ResolveEnv,
 		ReviewEnv,
 		ScheduleEnv,
 		ValidateEnv,
+		LintEnv,
+		WaitEnv,
+		BuildEnv,
+		PromoteEnv,
+		CloneEnv,
 	},
 }
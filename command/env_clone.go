@@ -0,0 +1,171 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package command
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	h "github.com/ernestio/ernest-cli/helper"
+	"github.com/ernestio/ernest-cli/manager"
+	"github.com/ernestio/ernest-cli/model"
+	"github.com/fatih/color"
+	"github.com/urfave/cli"
+
+	emodels "github.com/ernestio/ernest-go-sdk/models"
+)
+
+// CloneEnv command
+// Creates an environment with the settings of another one
+var CloneEnv = cli.Command{
+	Name:        "clone",
+	Usage:       h.T("envs.clone.usage"),
+	ArgsUsage:   h.T("envs.clone.args"),
+	Description: h.T("envs.clone.description"),
+	Flags: append([]cli.Flag{
+		tBoolFlag("envs.clone.flags.with-credentials"),
+		tStringFlagND("envs.clone.flags.credentials"),
+		tBoolFlag("envs.clone.flags.skip-members"),
+		tBoolFlag("envs.clone.flags.apply"),
+		ProgressFlag,
+		TimeoutFlag,
+	}, AllProviderFlags...),
+	Action: func(c *cli.Context) error {
+		paramsLenValidation(c, 3, "envs.clone.args")
+		client := esetup(c, AuthUsersValidation)
+
+		project := c.Args()[0]
+		name := c.Args()[2]
+
+		src, err := client.Environment().Get(project, c.Args()[1])
+		checkError(err)
+		if _, err := client.Environment().Get(project, name); err == nil {
+			h.PrintError(fmt.Sprintf(h.T("envs.clone.exists"), project, name))
+		} else if !manager.IsNotFound(err) {
+			checkError(err)
+		}
+
+		env := emodels.Environment{
+			Name:        name,
+			Project:     project,
+			Options:     copyValues(src.Options),
+			Credentials: cloneCredentials(c, client, src),
+		}
+		checkError(client.Environment().Create(project, &env))
+
+		// the environment exists from here on, the remaining steps are
+		// reported instead of leaving it half configured silently
+		var failed []string
+		if len(src.Schedules) > 0 {
+			created, err := client.Environment().Get(project, name)
+			if err == nil {
+				created.Schedules = copyValues(src.Schedules)
+				err = client.Environment().Update(created)
+			}
+			if err != nil {
+				failed = append(failed, fmt.Sprintf(h.T("envs.clone.errors.schedules"), err.Error()))
+			}
+		}
+
+		if !c.Bool("skip-members") {
+			for _, m := range src.Members {
+				// the user cloning the environment already owns it
+				if m.User == client.Config().User {
+					continue
+				}
+				role := emodels.Role{
+					ID:       project + "/" + name,
+					User:     m.User,
+					Role:     m.Role,
+					Resource: "environment",
+				}
+				if err := client.Role().Create(&role); err != nil {
+					failed = append(failed, fmt.Sprintf(h.T("envs.clone.errors.member"), m.User, err.Error()))
+				}
+			}
+		}
+
+		if len(failed) > 0 {
+			color.Yellow(fmt.Sprintf(h.T("envs.clone.partial"), project, name))
+			h.PrintError(strings.Join(failed, "\n"))
+		}
+		color.Green(fmt.Sprintf(h.T("envs.clone.success"), project, c.Args()[1], project, name))
+
+		if !c.Bool("apply") {
+			return nil
+		}
+
+		def := model.Definition{}
+		if err := def.Load(buildDefinition(client, project, c.Args()[1], manager.BuildLatest)); err != nil {
+			h.PrintError("Could not process definition yaml")
+		}
+		def.Rename(project, name)
+		payload, err := def.Save()
+		if err != nil {
+			h.PrintError("Could not finalize definition yaml")
+		}
+		submitDefinition(c, client, project, name, payload)
+
+		return nil
+	},
+}
+
+// copyValues : copies the first level of a map of settings, so the copy
+// can be changed without altering the source
+func copyValues(values map[string]interface{}) map[string]interface{} {
+	if values == nil {
+		return nil
+	}
+	cp := make(map[string]interface{}, len(values))
+	for k, v := range values {
+		cp[k] = v
+	}
+	return cp
+}
+
+// cloneCredentials : gets the credentials of a cloned environment, the
+// ones of the source environment with --with-credentials overridden by
+// the ones given with --credentials or the provider flags. Values masked
+// by the api can't be copied and have to be given again
+func cloneCredentials(c *cli.Context, client *manager.Client, src *emodels.Environment) map[string]interface{} {
+	given := envCredentials(c, client, src.Project)
+	if !c.Bool("with-credentials") {
+		return given
+	}
+
+	creds := copyValues(src.Credentials)
+	if creds == nil {
+		creds = make(map[string]interface{})
+	}
+	for k, v := range given {
+		creds[k] = v
+	}
+
+	var masked []string
+	for k, v := range creds {
+		if isMasked(v) {
+			masked = append(masked, k)
+		}
+	}
+	if len(masked) > 0 {
+		sort.Strings(masked)
+		h.PrintErrorCode(fmt.Sprintf(h.T("envs.clone.errors.masked"), strings.Join(masked, ", ")), h.ExitUsage)
+	}
+
+	return creds
+}
+
+// isMasked : checks if a credential value has been hidden by the api
+func isMasked(v interface{}) bool {
+	s, ok := v.(string)
+	if !ok || s == "" {
+		return false
+	}
+	if strings.Trim(s, "*") == "" {
+		return true
+	}
+	return strings.EqualFold(strings.Trim(s, "[]<>"), "redacted")
+}
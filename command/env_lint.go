@@ -0,0 +1,57 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package command
+
+import (
+	"io/ioutil"
+
+	h "github.com/ernestio/ernest-cli/helper"
+	"github.com/ernestio/ernest-cli/model"
+	"github.com/ernestio/ernest-cli/view"
+	"github.com/urfave/cli"
+)
+
+// LintEnv command
+// Validates a definition file without contacting ernest
+var LintEnv = cli.Command{
+	Name:        "lint",
+	Usage:       h.T("envs.lint.usage"),
+	ArgsUsage:   h.T("envs.lint.args"),
+	Description: h.T("envs.lint.description"),
+	Flags: []cli.Flag{
+		tStringFlagND("envs.lint.flags.provider"),
+		tStringSliceFlag("envs.lint.flags.var"),
+		tStringSliceFlag("envs.lint.flags.var-file"),
+		tBoolFlag("envs.lint.flags.confine-imports"),
+	},
+	Action: func(c *cli.Context) error {
+		setupGlobals(c)
+
+		file := "ernest.yml"
+		if len(c.Args()) > 0 {
+			file = c.Args()[0]
+		}
+		payload, err := ioutil.ReadFile(file)
+		if err != nil {
+			h.PrintErrorCode("You should specify a valid template path or store an ernest.yml on the current folder", h.ExitUsage)
+		}
+
+		result, err := model.Lint(file, payload, model.LintOptions{
+			Provider:  c.String("provider"),
+			Variables: mapVariables(c),
+			Confine:   c.Bool("confine-imports"),
+		})
+		if err != nil {
+			h.PrintErrorCode(err.Error(), h.ExitUsage)
+		}
+
+		view.PrintLintResult(result)
+		if result.Errors() > 0 {
+			h.Exit(h.ExitValidation)
+		}
+
+		return nil
+	},
+}
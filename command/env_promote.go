@@ -0,0 +1,126 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package command
+
+import (
+	"fmt"
+
+	h "github.com/ernestio/ernest-cli/helper"
+	"github.com/ernestio/ernest-cli/manager"
+	"github.com/ernestio/ernest-cli/model"
+	"github.com/ernestio/ernest-cli/view"
+	"github.com/fatih/color"
+	"github.com/urfave/cli"
+
+	emodels "github.com/ernestio/ernest-go-sdk/models"
+)
+
+// PromoteEnv command
+// Applies the definition of an environment to another one
+var PromoteEnv = cli.Command{
+	Name:        "promote",
+	Usage:       h.T("envs.promote.usage"),
+	ArgsUsage:   h.T("envs.promote.args"),
+	Description: h.T("envs.promote.description"),
+	Flags: []cli.Flag{
+		tStringFlagND("envs.promote.flags.overrides"),
+		tStringSliceFlag("envs.promote.flags.var"),
+		tStringSliceFlag("envs.promote.flags.var-file"),
+		tBoolFlag("envs.promote.flags.dry"),
+		tBoolFlag("envs.promote.flags.yesflag"),
+		ProgressFlag,
+		TimeoutFlag,
+	},
+	Action: func(c *cli.Context) error {
+		paramsLenValidation(c, 2, "envs.promote.args")
+		srcProject, srcEnv, srcBuild := parseEnvRef(c.Args()[0])
+		dstProject, dstEnv, _ := parseEnvRef(c.Args()[1])
+		if srcProject == dstProject && srcEnv == dstEnv {
+			h.PrintErrorCode(h.T("envs.promote.same"), h.ExitUsage)
+		}
+		client := esetup(c, AuthUsersValidation)
+
+		def := model.Definition{}
+		if err := def.Load(buildDefinition(client, srcProject, srcEnv, srcBuild)); err != nil {
+			h.PrintError("Could not process definition yaml")
+		}
+		if file := c.String("overrides"); file != "" {
+			if err := def.Override(file, mapVariables(c)); err != nil {
+				h.PrintErrorCode(err.Error(), h.ExitValidation)
+			}
+			if err := def.LoadFileImports(); err != nil {
+				h.PrintError(err.Error())
+			}
+		}
+		def.Rename(dstProject, dstEnv)
+
+		payload, err := def.Save()
+		if err != nil {
+			h.PrintError("Could not finalize definition yaml")
+		}
+
+		_, err = client.Environment().Get(dstProject, dstEnv)
+		exists := !manager.IsNotFound(err)
+		if exists {
+			checkError(err)
+		}
+
+		var current []byte
+		if exists {
+			build, err := client.Build().Resolve(dstProject, dstEnv, manager.BuildLatest)
+			if !manager.IsNotFound(err) {
+				checkError(err)
+				d, err := client.Build().Definition(dstProject, dstEnv, build.ID)
+				checkError(err)
+				current = []byte(d)
+			}
+		}
+		changelog, err := model.DiffDefinitions(current, payload)
+		if err != nil {
+			h.PrintError(err.Error())
+		}
+		if len(changelog) == 0 {
+			color.Green(fmt.Sprintf(h.T("envs.promote.up_to_date"), dstProject, dstEnv))
+			return nil
+		}
+		view.PrintDiff(&changelog)
+
+		if c.Bool("dry") {
+			return nil
+		}
+		if !c.Bool("yes") {
+			fmt.Printf(h.T("envs.promote.confirmation"), srcProject, srcEnv, dstProject, dstEnv)
+			if !askForConfirmation() {
+				return nil
+			}
+		}
+
+		if !exists {
+			env := emodels.Environment{
+				Name:    dstEnv,
+				Project: dstProject,
+			}
+			checkError(client.Environment().Create(dstProject, &env))
+		}
+
+		submitDefinition(c, client, dstProject, dstEnv, payload)
+
+		return nil
+	},
+}
+
+// submitDefinition : creates a build applying a definition to an
+// environment, following its progress unless it awaits approval
+func submitDefinition(c *cli.Context, client *manager.Client, project, env string, payload []byte) {
+	build, err := client.Build().Create(payload)
+	checkError(err)
+	if build.Status == "submitted" {
+		color.Green("Build has been succesfully submitted and is awaiting approval.")
+		h.Exit(h.ExitAwaiting)
+	}
+
+	monitorBuild(c, client, project, env, build.ID)
+	printBuildInfo(c, client, project, env, build.GetID())
+}
@@ -0,0 +1,86 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package command
+
+import (
+	"fmt"
+	"time"
+
+	h "github.com/ernestio/ernest-cli/helper"
+	"github.com/urfave/cli"
+)
+
+// waitInterval : time between build status checks
+const waitInterval = 5 * time.Second
+
+// waitExitCodes : exit codes for the build statuses a wait finishes on
+var waitExitCodes = map[string]int{
+	"done":                h.ExitSuccess,
+	"errored":             h.ExitBuildErrored,
+	"awaiting_resolution": h.ExitAwaiting,
+}
+
+// WaitEnv command
+// Waits for a build to reach a final status without streaming its progress
+var WaitEnv = cli.Command{
+	Name:        "wait",
+	Usage:       h.T("envs.wait.usage"),
+	ArgsUsage:   h.T("envs.wait.args"),
+	Description: h.T("envs.wait.description"),
+	Flags: []cli.Flag{
+		tStringFlagND("envs.wait.flags.build"),
+		tStringFlagND("envs.wait.flags.for"),
+		TimeoutFlag,
+	},
+	Action: func(c *cli.Context) error {
+		paramsLenValidation(c, 2, "envs.wait.args")
+		target := c.String("for")
+		if _, ok := waitExitCodes[target]; target != "" && !ok {
+			h.PrintErrorCode("Invalid --for status '"+target+"', valid statuses are done, errored and awaiting_resolution", h.ExitUsage)
+		}
+		client := esetup(c, AuthUsersValidation)
+
+		project := c.Args()[0]
+		env := c.Args()[1]
+
+		build, err := client.Build().Resolve(project, env, c.String("build"))
+		checkError(err)
+
+		expires := waitDeadline(c)
+
+		status := ""
+		for {
+			build, err = client.Build().Get(project, env, build.ID)
+			checkError(err)
+
+			if build.Status != status {
+				status = build.Status
+				fmt.Printf("%s Build %s is %s\n", time.Now().Format(time.RFC3339), build.ID, status)
+			}
+
+			if code, ok := waitExitCodes[status]; ok {
+				if status == target {
+					return nil
+				}
+				if target != "" && code == h.ExitSuccess {
+					h.PrintError(fmt.Sprintf("Build %s finished as %s instead of %s", build.ID, status, target))
+				}
+				h.Exit(code)
+			}
+
+			wait := waitInterval
+			if !expires.IsZero() {
+				remaining := time.Until(expires)
+				if remaining <= 0 {
+					h.PrintErrorCode(fmt.Sprintf("Timed out waiting for build %s, its status is %s", build.ID, status), h.ExitTimeout)
+				}
+				if remaining < wait {
+					wait = remaining
+				}
+			}
+			time.Sleep(wait)
+		}
+	},
+}
@@ -0,0 +1,148 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package command
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	h "github.com/ernestio/ernest-cli/helper"
+	"github.com/ernestio/ernest-cli/manager"
+	"github.com/ernestio/ernest-cli/view"
+	isatty "github.com/mattn/go-isatty"
+	"github.com/urfave/cli"
+)
+
+var exitCodes = map[manager.ErrorKind]int{
+	manager.ErrNotFound:     h.ExitNotFound,
+	manager.ErrUnauthorized: h.ExitUnauthorized,
+	manager.ErrForbidden:    h.ExitUnauthorized,
+	manager.ErrValidation:   h.ExitValidation,
+	manager.ErrUsage:        h.ExitUsage,
+}
+
+// checkError : presents an error returned by the manager and exits,
+// validation failures are printed with their full report
+func checkError(err error) {
+	if err == nil {
+		return
+	}
+
+	if e, ok := err.(*manager.Error); ok && e.Validation != nil {
+		view.PrintValidation(e.Validation)
+	}
+
+	h.PrintErrorCode(err.Error(), exitCode(err))
+}
+
+// exitCode : gets the exit code matching an error returned by the manager
+func exitCode(err error) int {
+	if code, ok := exitCodes[manager.Kind(err)]; ok {
+		return code
+	}
+	return h.ExitFailure
+}
+
+// maxReconnects : times a lost build stream is reopened before giving up
+const maxReconnects = 5
+
+// monitorBuild : follows the progress of a build, reconnecting with backoff
+// when its stream is lost. Exits with a build errored code if it fails
+func monitorBuild(c *cli.Context, client *manager.Client, project, env, id string) {
+	mode := progressMode(c)
+	monitor := h.NewMonitor(mode, c.Duration("timeout"))
+
+	stream, err := client.Build().Stream(id)
+	checkError(err)
+
+	backoff := time.Second
+	for attempt := 1; ; attempt++ {
+		err = monitor.Follow(stream)
+		if err != h.ErrStreamLost {
+			break
+		}
+
+		// the stream may have been closed after the build finished,
+		// its status tells if any final event was missed
+		if build, gerr := client.Build().Get(project, env, id); gerr == nil {
+			// syncs detecting changes finish awaiting their resolution
+			if build.Status == "done" || build.Status == "awaiting_resolution" {
+				err = nil
+				break
+			}
+			if build.Status == "errored" {
+				err = h.ErrBuildFailed
+				break
+			}
+		}
+
+		if attempt > maxReconnects {
+			break
+		}
+		if monitor.Expired() {
+			err = h.ErrMonitorTimeout
+			break
+		}
+
+		fmt.Fprintf(os.Stderr, "Build stream lost, reconnecting in %s\n", backoff)
+		time.Sleep(backoff)
+		if backoff *= 2; backoff > 30*time.Second {
+			backoff = 30 * time.Second
+		}
+
+		if stream, err = client.Build().Stream(id); err != nil {
+			stream = make(chan []byte)
+			close(stream)
+		}
+	}
+	monitor.Stop()
+
+	switch err {
+	case nil:
+		return
+	case h.ErrBuildFailed:
+		if mode == h.ProgressJSON {
+			h.Exit(h.ExitBuildErrored)
+		}
+		h.PrintErrorCode(h.T("monitor.errored"), h.ExitBuildErrored)
+	case h.ErrStreamLost:
+		h.PrintError(fmt.Sprintf(h.T("monitor.lost"), project, env))
+	case h.ErrMonitorTimeout:
+		h.PrintErrorCode(fmt.Sprintf(h.T("monitor.timeout"), project, env), h.ExitTimeout)
+	}
+	h.EvaluateError(err)
+}
+
+// printBuildInfo : prints the environment info once a monitored build
+// finishes, skipped on json progress so its output is only the events
+func printBuildInfo(c *cli.Context, client *manager.Client, project, env, id string) {
+	if progressMode(c) == h.ProgressJSON {
+		return
+	}
+
+	e, err := client.Environment().Get(project, env)
+	checkError(err)
+	build, err := client.Build().Get(project, env, id)
+	checkError(err)
+	view.PrintEnvInfo(e, build)
+}
+
+// progressMode : gets the --progress mode, defaulting to the animated
+// output on terminals and to plain lines otherwise
+func progressMode(c *cli.Context) string {
+	switch mode := c.String("progress"); mode {
+	case h.ProgressTTY, h.ProgressPlain, h.ProgressJSON:
+		return mode
+	case "":
+	default:
+		h.PrintErrorCode("Invalid progress mode '"+mode+"', valid modes are plain, json and tty", h.ExitUsage)
+	}
+
+	if isatty.IsTerminal(os.Stdout.Fd()) || isatty.IsCygwinTerminal(os.Stdout.Fd()) {
+		return h.ProgressTTY
+	}
+	return h.ProgressPlain
+}
@@ -0,0 +1,162 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package command
+
+import (
+	"strings"
+	"time"
+
+	h "github.com/ernestio/ernest-cli/helper"
+	"github.com/ernestio/ernest-cli/manager"
+	"github.com/ernestio/ernest-cli/model"
+	"github.com/urfave/cli"
+
+	emodels "github.com/ernestio/ernest-go-sdk/models"
+)
+
+// historyFilter : selects the builds shown on an environment history
+type historyFilter struct {
+	since  time.Time
+	until  time.Time
+	status string
+	typ    string
+	user   string
+	limit  int
+	// changes fetches the changelog of every build shown
+	changes bool
+}
+
+// mapHistoryFilter : gets the history filter from the command flags
+func mapHistoryFilter(c *cli.Context) historyFilter {
+	f := historyFilter{
+		status:  c.String("status"),
+		typ:     c.String("type"),
+		user:    c.String("user"),
+		limit:   c.Int("limit"),
+		changes: c.Bool("changes"),
+	}
+	if f.limit < 0 {
+		h.PrintErrorCode("--limit should be a positive number", h.ExitUsage)
+	}
+
+	var err error
+	now := time.Now()
+	if s := c.String("since"); s != "" {
+		if f.since, err = model.ParseTimeFilter(s, now); err != nil {
+			h.PrintErrorCode(err.Error(), h.ExitUsage)
+		}
+	}
+	if s := c.String("until"); s != "" {
+		if f.until, err = model.ParseTimeFilter(s, now); err != nil {
+			h.PrintErrorCode(err.Error(), h.ExitUsage)
+		}
+	}
+
+	return f
+}
+
+func (f historyFilter) match(b *emodels.Build) bool {
+	if f.status != "" && !strings.EqualFold(b.Status, f.status) {
+		return false
+	}
+	if f.typ != "" && !strings.EqualFold(b.Type, f.typ) {
+		return false
+	}
+	if f.user != "" && !strings.EqualFold(b.Username, f.user) {
+		return false
+	}
+	if f.since.IsZero() && f.until.IsZero() {
+		return true
+	}
+
+	created, err := model.ParseBuildTime(b.CreatedAt)
+	if err != nil {
+		return false
+	}
+	if !f.since.IsZero() && created.Before(f.since) {
+		return false
+	}
+	if !f.until.IsZero() && created.After(f.until) {
+		return false
+	}
+
+	return true
+}
+
+// historyWorkers : changelogs fetched at the same time for the history
+const historyWorkers = 4
+
+// historyEntries : filters the builds of an environment, newest first, and
+// summarizes them with their duration. The number of changed components
+// is only fetched when requested, for the builds left after filtering
+func historyEntries(client *manager.Client, project, env string, builds []*emodels.Build, f historyFilter) []model.HistoryEntry {
+	entries := make([]model.HistoryEntry, 0)
+	for i, b := range builds {
+		if f.limit > 0 && len(entries) == f.limit {
+			break
+		}
+		if !f.match(b) {
+			continue
+		}
+
+		entries = append(entries, model.HistoryEntry{
+			Position:  len(builds) - i,
+			ID:        b.ID,
+			Type:      b.Type,
+			Status:    b.Status,
+			User:      b.Username,
+			CreatedAt: b.CreatedAt,
+			UpdatedAt: b.UpdatedAt,
+			Duration:  buildDuration(b),
+			Tags:      client.Config().BuildTagNames(project, env, b.ID),
+			Message:   strings.Join(client.Config().BuildTagMessages(project, env, b.ID), "; "),
+		})
+	}
+
+	if f.changes {
+		forEach(historyWorkers, len(entries), func(i int) {
+			entries[i].Changes = changedComponents(client, project, env, entries[i].ID)
+		})
+	}
+
+	return entries
+}
+
+// buildDuration : time a finished build took to run
+func buildDuration(b *emodels.Build) string {
+	if b.Status == "in_progress" || b.Status == "submitted" {
+		return ""
+	}
+
+	created, err := model.ParseBuildTime(b.CreatedAt)
+	if err != nil {
+		return ""
+	}
+	updated, err := model.ParseBuildTime(b.UpdatedAt)
+	if err != nil || updated.Before(created) {
+		return ""
+	}
+
+	return updated.Sub(created).Round(time.Second).String()
+}
+
+// changedComponents : number of components changed by a build, nil when
+// its changelog is not available
+func changedComponents(client *manager.Client, project, env, id string) *int {
+	changelog, err := client.Build().Changelog(project, env, id)
+	if err != nil || changelog == nil {
+		return nil
+	}
+
+	components := make(map[string]bool)
+	for _, change := range *changelog {
+		if len(change.Path) > 0 {
+			components[change.Path[0]] = true
+		}
+	}
+	n := len(components)
+
+	return &n
+}
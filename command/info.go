@@ -5,9 +5,8 @@
 package command
 
 import (
-	"fmt"
-
 	h "github.com/ernestio/ernest-cli/helper"
+	"github.com/ernestio/ernest-cli/view"
 	"github.com/urfave/cli"
 )
 
@@ -21,9 +20,16 @@ var Info = cli.Command{
 	Description: h.T("info.description"),
 	Action: func(c *cli.Context) error {
 		client := esetup(c, NoValidation)
-		fmt.Println("Target:      " + client.Config().URL)
-		fmt.Println("User:        " + client.Config().User)
-		fmt.Println("CLI Version: " + c.App.Version)
+		info := view.Info{
+			Profile: client.Config().Name,
+			Target:  client.Config().URL,
+			User:    client.Config().User,
+			Version: c.App.Version,
+		}
+		if expiry, ok := client.Config().TokenExpiry(); ok {
+			info.Expires = &expiry
+		}
+		view.PrintInfo(info)
 
 		return nil
 	},
@@ -25,10 +25,13 @@ var CmdLog = cli.Command{
 	Action: func(c *cli.Context) error {
 		client := esetup(c, AuthUsersValidation)
 
+		stream, err := client.Logger().Stream()
+		checkError(err)
+
 		if c.Bool("raw") {
-			_ = helper.PrintRawLogs(client.Logger().Stream())
+			_ = helper.PrintRawLogs(stream)
 		} else {
-			_ = helper.PrintLogs(client.Logger().Stream())
+			_ = helper.PrintLogs(stream)
 		}
 
 		runtime.Goexit()
@@ -24,12 +24,15 @@ var Login = cli.Command{
 	Usage:       h.T("login.usage"),
 	ArgsUsage:   h.T("login.args"),
 	Description: h.T("login.description"),
-	Flags: []cli.Flag{
+	Flags: append([]cli.Flag{
 		tStringFlag("login.flags.user"),
 		tStringFlag("login.flags.password"),
 		tStringFlag("login.flags.verification"),
-	},
+	}, MFAFlags...),
 	Action: func(c *cli.Context) error {
+		setupGlobals(c)
+		SetupMFA(c)
+
 		var username string
 		var password string
 		var verificationCode string
@@ -64,25 +67,17 @@ var Login = cli.Command{
 				}
 			}
 		} else {
-			password = c.String("password")
+			password = flagString(c, "password")
 		}
 
 		verificationCode = c.String("verification-code")
-		client := elogin(username, password, verificationCode)
-		token, err := client.Cli().Authenticate()
-
-		// MFA check
-		if err != nil && err.Error() == "mfa required" {
+		client, token, err := eauthenticate(username, password, verificationCode, func() (string, error) {
 			fmt.Printf("Verification code: ")
-			vc, _ := gopass.GetPasswdMasked()
-			verificationCode = string(vc)
-
-			client = elogin(username, password, verificationCode)
-			token, err = client.Cli().Authenticate()
-		}
-
+			vc, err := gopass.GetPasswdMasked()
+			return string(vc), err
+		})
 		if err != nil {
-			h.PrintError(err.Error())
+			h.PrintErrorCode(err.Error(), h.ExitUnauthorized)
 		}
 
 		cfg := client.Config()
@@ -0,0 +1,118 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package command
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	h "github.com/ernestio/ernest-cli/helper"
+	"github.com/ernestio/ernest-cli/manager"
+	"github.com/urfave/cli"
+)
+
+// MFAFlags : flags giving a source of verification codes, so users with
+// MFA enabled can log in without being prompted
+var MFAFlags = []cli.Flag{
+	tStringFlagND("login.flags.totp_secret_file"),
+	tStringFlagND("login.flags.verification_command"),
+}
+
+// mfa : source of verification codes given with MFAFlags
+var mfa struct {
+	secretFile string
+	command    string
+}
+
+// SetupMFA : reads the source of verification codes given to a command
+func SetupMFA(c *cli.Context) {
+	mfa.secretFile = c.String("totp-secret-file")
+	mfa.command = c.String("verification-command")
+}
+
+// verificationCode : gets a verification code without prompting for it,
+// from a command, a TOTP secret file or the ERNEST_TOTP_SECRET environment
+// variable. Reports false when none of them is given
+func verificationCode() (string, bool, error) {
+	switch {
+	case mfa.command != "":
+		code, err := runVerificationCommand(mfa.command)
+		return code, true, err
+	case mfa.secretFile != "":
+		secret, err := ioutil.ReadFile(mfa.secretFile)
+		if err != nil {
+			return "", true, errors.New("Can't read TOTP secret file " + mfa.secretFile)
+		}
+		code, err := h.TOTP(string(secret), time.Now())
+		return code, true, err
+	case os.Getenv("ERNEST_TOTP_SECRET") != "":
+		code, err := h.TOTP(os.Getenv("ERNEST_TOTP_SECRET"), time.Now())
+		return code, true, err
+	}
+
+	return "", false, nil
+}
+
+// runVerificationCommand : runs a command through the shell, reading the
+// verification code from its output. Its error output is shown, so it can
+// prompt for input
+func runVerificationCommand(command string) (string, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", command)
+	} else {
+		cmd = exec.Command("sh", "-c", command)
+	}
+
+	var stdout bytes.Buffer
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", errors.New("Verification command failed: " + err.Error())
+	}
+
+	code := strings.TrimSpace(stdout.String())
+	if code == "" {
+		return "", errors.New("Verification command didn't output a code")
+	}
+
+	return code, nil
+}
+
+// Eauthenticate : logs in, getting a verification code when MFA is
+// required from the given source or the prompt otherwise
+func Eauthenticate(usr, pwd, vc string, prompt func() (string, error)) (*manager.Client, string, error) {
+	return eauthenticate(usr, pwd, vc, prompt)
+}
+
+func eauthenticate(usr, pwd, vc string, prompt func() (string, error)) (*manager.Client, string, error) {
+	client := elogin(usr, pwd, vc)
+	token, err := client.Cli().Authenticate()
+	if err == nil || err.Error() != "mfa required" {
+		return client, token, err
+	}
+
+	vc, ok, err := verificationCode()
+	if err != nil {
+		return nil, "", err
+	}
+	if !ok {
+		if vc, err = prompt(); err != nil {
+			return nil, "", err
+		}
+	}
+
+	client = elogin(usr, pwd, vc)
+	token, err = client.Cli().Authenticate()
+
+	return client, token, err
+}
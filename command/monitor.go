@@ -27,17 +27,24 @@ var MonitorEnv = cli.Command{
 	Usage:       h.T("monitor.usage"),
 	ArgsUsage:   h.T("monitor.args"),
 	Description: h.T("monitor.description"),
+	Flags: []cli.Flag{
+		ProgressFlag,
+		TimeoutFlag,
+	},
 	Action: func(c *cli.Context) error {
 		paramsLenValidation(c, 2, "monitor.args")
 		client := esetup(c, AuthUsersValidation)
 
-		build := client.Build().BuildByPosition(c.Args()[0], c.Args()[1], "")
+		build, err := client.Build().Resolve(c.Args()[0], c.Args()[1], "")
+		checkError(err)
 
 		if build.Status == "done" {
 			color.Yellow(h.T("monitor.success_1"))
 			color.Yellow(fmt.Sprintf(h.T("monitor.success_2"), c.Args()[0], c.Args()[1]))
 			return nil
 		}
-		return h.Monitorize(client.Build().Stream(build.ID))
+		monitorBuild(c, client, c.Args()[0], c.Args()[1], build.ID)
+
+		return nil
 	},
 }
@@ -24,7 +24,8 @@ var ListNotifications = cli.Command{
 	Description: h.T("notification.list.description"),
 	Action: func(c *cli.Context) error {
 		client := esetup(c, AuthUsersValidation)
-		notifications := client.Notification().List()
+		notifications, err := client.Notification().List()
+		checkError(err)
 
 		view.PrintNotificationList(notifications)
 
@@ -43,7 +44,7 @@ var DeleteNotification = cli.Command{
 		name := c.Args()[0]
 		client := esetup(c, AuthUsersValidation)
 
-		client.Notification().Delete(name)
+		checkError(client.Notification().Delete(name))
 		color.Green(fmt.Sprintf(h.T("notification.delete.success"), name))
 		return nil
 	},
@@ -61,9 +62,10 @@ var UpdateNotification = cli.Command{
 		name := c.Args()[0]
 		notificationConfig := c.Args()[1]
 
-		n := client.Notification().Get(name)
+		n, err := client.Notification().Get(name)
+		checkError(err)
 		n.Config = notificationConfig
-		client.Notification().Update(n)
+		checkError(client.Notification().Update(n))
 		color.Green(fmt.Sprintf(h.T("notification.update.success"), name))
 		return nil
 	},
@@ -84,9 +86,9 @@ var AddEntityToNotification = cli.Command{
 		entity := project
 		if len(c.Args()) > 2 {
 			entity = entity + "/" + c.Args()[2]
-			client.Notification().AddEnv(notification, project, c.Args()[2])
+			checkError(client.Notification().AddEnv(notification, project, c.Args()[2]))
 		} else {
-			client.Notification().AddProject(notification, project)
+			checkError(client.Notification().AddProject(notification, project))
 		}
 
 		color.Green(fmt.Sprintf(h.T("notification.service.add.success"), entity, notification))
@@ -109,9 +111,9 @@ var RmEntityToNotification = cli.Command{
 		entity := project
 		if len(c.Args()) > 2 {
 			entity = entity + "/" + c.Args()[2]
-			client.Notification().RmEnv(notification, project, c.Args()[2])
+			checkError(client.Notification().RmEnv(notification, project, c.Args()[2]))
 		} else {
-			client.Notification().RmProject(notification, project)
+			checkError(client.Notification().RmProject(notification, project))
 		}
 
 		color.Green(fmt.Sprintf(h.T("notification.service.rm.success"), entity, notification))
@@ -137,7 +139,7 @@ var CreateNotification = cli.Command{
 			Type:   notificationType,
 			Config: notificationConfig,
 		}
-		client.Notification().Create(&notification)
+		checkError(client.Notification().Create(&notification))
 		color.Green(fmt.Sprintf(h.T("notification.create.success"), name))
 		return nil
 	},
@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	h "github.com/ernestio/ernest-cli/helper"
+	"github.com/ernestio/ernest-cli/model"
 	"github.com/ernestio/ernest-cli/view"
 	"github.com/fatih/color"
 	"github.com/urfave/cli"
@@ -26,7 +27,8 @@ var ListPolicies = cli.Command{
 	Description: h.T("policy.list.description"),
 	Action: func(c *cli.Context) error {
 		client := esetup(c, AuthUsersValidation)
-		policies := client.Policy().List()
+		policies, err := client.Policy().List()
+		checkError(err)
 
 		view.PrintPolicyList(policies)
 
@@ -51,7 +53,7 @@ var DeletePolicy = cli.Command{
 		client := esetup(c, AuthUsersValidation)
 		name := flags["policy-name"].(string)
 
-		client.Policy().Delete(name)
+		checkError(client.Policy().Delete(name))
 		color.Green(fmt.Sprintf(h.T("policy.delete.success"), name))
 		return nil
 	},
@@ -79,7 +81,7 @@ var UpdatePolicy = cli.Command{
 			h.PrintError(h.T("policy.update.errors.spec"))
 		}
 
-		client.Policy().CreateDocument(name, string(spec))
+		checkError(client.Policy().CreateDocument(name, string(spec)))
 		color.Green(fmt.Sprintf(h.T("policy.update.success"), name))
 		return nil
 	},
@@ -109,8 +111,8 @@ var CreatePolicy = cli.Command{
 		policy := emodels.Policy{
 			Name: flags["policy-name"].(string),
 		}
-		client.Policy().Create(&policy)
-		client.Policy().CreateDocument(policy.Name, string(spec))
+		checkError(client.Policy().Create(&policy))
+		checkError(client.Policy().CreateDocument(policy.Name, string(spec)))
 		color.Green(fmt.Sprintf(h.T("policy.create.success"), policy.Name))
 		return nil
 	},
@@ -133,7 +135,8 @@ var ShowPolicy = cli.Command{
 		})
 		client := esetup(c, AuthUsersValidation)
 
-		n := client.Policy().GetDocument(flags["policy-name"].(string), flags["revision"].(string))
+		n, err := client.Policy().GetDocument(flags["policy-name"].(string), flags["revision"].(string))
+		checkError(err)
 		fmt.Println(n.Definition)
 		return nil
 	},
@@ -154,7 +157,8 @@ var HistoryPolicy = cli.Command{
 		})
 		client := esetup(c, AuthUsersValidation)
 
-		documents := client.Policy().ListDocuments(flags["policy-name"].(string))
+		documents, err := client.Policy().ListDocuments(flags["policy-name"].(string))
+		checkError(err)
 		view.PrintPolicyHistory(documents)
 
 		return nil
@@ -167,11 +171,15 @@ var AttachPolicy = cli.Command{
 	Usage:       h.T("policy.attach.usage"),
 	ArgsUsage:   h.T("policy.attach.args"),
 	Description: h.T("policy.attach.description"),
-	Flags: []cli.Flag{
+	Flags: append([]cli.Flag{
 		tStringFlag("policy.attach.flags.name"),
 		tStringFlag("policy.attach.flags.environment"),
-	},
+	}, FilterFlags...),
 	Action: func(c *cli.Context) error {
+		if c.String("project") != "" || c.String("status") != "" || model.IsGlob(c.String("environment")) {
+			bulkAttachPolicy(c)
+			return nil
+		}
 		flags := parseTemplateFlags(c, map[string]flagDef{
 			"policy-name": flagDef{typ: "string", req: true},
 			"environment": flagDef{typ: "string", req: true},
@@ -183,21 +191,74 @@ var AttachPolicy = cli.Command{
 			h.PrintError(h.T("policy.attach.errors.invalid_name"))
 		}
 
-		p := client.Policy().Get(flags["policy-name"].(string))
-		_ = client.Environment().Get(parts[0], parts[1])
+		p, err := client.Policy().Get(flags["policy-name"].(string))
+		checkError(err)
+		_, err = client.Environment().Get(parts[0], parts[1])
+		checkError(err)
 		for _, v := range p.Environments {
 			if v == env {
 				h.PrintError(h.T("policy.attach.errors.already_attached"))
 			}
 		}
 		p.Environments = append(p.Environments, env)
-		client.Policy().Update(p)
+		checkError(client.Policy().Update(p))
 
 		color.Green(fmt.Sprintf(h.T("policy.attach.success"), p.Name, env))
 		return nil
 	},
 }
 
+// bulkAttachPolicy : attaches a policy to all environments matching a
+// selector, the environment flag accepts glob patterns as project/env
+func bulkAttachPolicy(c *cli.Context) {
+	requiredFlags(c, []string{"policy-name"})
+	sel := model.EnvSelector{
+		Project: c.String("project"),
+		Status:  c.String("status"),
+	}
+	if env := c.String("environment"); env != "" {
+		parts := strings.Split(env, "/")
+		if len(parts) != 2 {
+			h.PrintErrorCode(h.T("policy.attach.errors.invalid_name"), h.ExitUsage)
+		}
+		sel.Project, sel.Name = parts[0], parts[1]
+	}
+	if err := sel.Validate(); err != nil {
+		h.PrintErrorCode(err.Error(), h.ExitUsage)
+	}
+	client := esetup(c, AuthUsersValidation)
+
+	p, err := client.Policy().Get(c.String("policy-name"))
+	checkError(err)
+	envs := selectEnvs(client, sel)
+
+	attached := make(map[string]bool)
+	for _, v := range p.Environments {
+		attached[v] = true
+	}
+
+	results := make([]model.BulkResult, 0, len(envs))
+	for _, env := range envs {
+		project, short := envRef(env)
+		name := project + "/" + short
+		r := model.BulkResult{Project: project, Environment: short, Result: model.BulkOK, Message: "Policy attached"}
+		if attached[name] {
+			r.Message = h.T("policy.attach.errors.already_attached")
+		} else {
+			p.Environments = append(p.Environments, name)
+		}
+		results = append(results, r)
+	}
+
+	if err := client.Policy().Update(p); err != nil {
+		for i := range results {
+			results[i].Result = model.BulkFailed
+			results[i].Message = err.Error()
+		}
+	}
+	printBulkResults(results)
+}
+
 // DetachPolicy : Display an existing policy
 var DetachPolicy = cli.Command{
 	Name:        "detach",
@@ -220,8 +281,10 @@ var DetachPolicy = cli.Command{
 			h.PrintError(h.T("policy.detach.errors.invalid_name"))
 		}
 
-		p := client.Policy().Get(flags["policy-name"].(string))
-		_ = client.Environment().Get(parts[0], parts[1])
+		p, err := client.Policy().Get(flags["policy-name"].(string))
+		checkError(err)
+		_, err = client.Environment().Get(parts[0], parts[1])
+		checkError(err)
 		var toBeAttached []string
 		for _, v := range p.Environments {
 			if v != env {
@@ -232,7 +295,7 @@ var DetachPolicy = cli.Command{
 			h.T("policy.detach.error.not_attached")
 		}
 		p.Environments = toBeAttached
-		client.Policy().Update(p)
+		checkError(client.Policy().Update(p))
 
 		color.Green(fmt.Sprintf(h.T("policy.detach.success"), p.Name, env))
 		return nil
@@ -23,7 +23,8 @@ var ListLoggers = cli.Command{
 	Action: func(c *cli.Context) error {
 		client := esetup(c, NonAdminValidation)
 
-		loggers := client.Logger().List()
+		loggers, err := client.Logger().List()
+		checkError(err)
 		view.PrintLoggerList(loggers)
 
 		return nil
@@ -54,7 +55,7 @@ var SetLogger = cli.Command{
 			Hostname:    c.String("hostname"),
 			Port:        c.Int("port"),
 			Timeout:     c.Int("timeout"),
-			Token:       c.String("token"),
+			Token:       flagString(c, "token"),
 			Environment: c.String("env"),
 		}
 		if logger.Type == "basic" {
@@ -83,7 +84,7 @@ var SetLogger = cli.Command{
 			return nil
 		}
 
-		client.Logger().Create(&logger)
+		checkError(client.Logger().Create(&logger))
 		color.Green(h.T("logger.set.success"))
 
 		return nil
@@ -100,7 +101,7 @@ var DelLogger = cli.Command{
 		paramsLenValidation(c, 1, "logger.del.args")
 		client := esetup(c, NonAdminValidation)
 
-		client.Logger().Delete(c.Args()[0])
+		checkError(client.Logger().Delete(c.Args()[0]))
 		color.Green(h.T("logger.del.success"))
 
 		return nil
@@ -19,7 +19,8 @@ var ListProjects = cli.Command{
 	Description: h.T("project.list.description"),
 	Action: func(c *cli.Context) error {
 		client := esetup(c, AuthUsersValidation)
-		projects := client.Project().List()
+		projects, err := client.Project().List()
+		checkError(err)
 		view.PrintProjectList(projects)
 
 		return nil
@@ -35,7 +36,8 @@ var InfoProject = cli.Command{
 	Action: func(c *cli.Context) error {
 		paramsLenValidation(c, 1, "project.info.args")
 		client := esetup(c, AuthUsersValidation)
-		p := client.Project().Get(c.Args()[0])
+		p, err := client.Project().Get(c.Args()[0])
+		checkError(err)
 		view.PrintProjectInfo(p)
 
 		return nil
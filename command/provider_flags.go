@@ -1,6 +1,7 @@
 package command
 
 import (
+	h "github.com/ernestio/ernest-cli/helper"
 	"github.com/urfave/cli"
 )
 
@@ -31,6 +32,31 @@ var ProviderAzureFlags = []cli.Flag{
 	tStringFlag("azure.update.flags.environment"),
 }
 
+// sensitiveFlags : flags holding secrets, besides literal values they
+// accept @<file>, - for stdin and env:<VAR>
+var sensitiveFlags = map[string]bool{
+	"password":          true,
+	"current-password":  true,
+	"secret_access_key": true,
+	"client_secret":     true,
+	"token":             true,
+}
+
+// flagString : gets the value of a string flag, resolving it when the
+// flag is sensitive
+func flagString(c *cli.Context, name string) string {
+	value := c.String(name)
+	if !sensitiveFlags[name] || value == "" {
+		return value
+	}
+
+	secret, err := h.ReadSecret(value)
+	if err != nil {
+		h.PrintErrorCode("Invalid --"+name+" value: "+err.Error(), h.ExitUsage)
+	}
+	return secret
+}
+
 // AWSVCloudFlags : All aws vcloud provider flags
 var AWSVCloudFlags = append(ProviderVCloudFlags, ProviderAWSFlags...)
 
@@ -57,7 +83,7 @@ func ProviderFlagsToSlice(c *cli.Context) map[string]interface{} {
 	flags := make(map[string]interface{}, 0)
 
 	for key, val := range keys {
-		v := c.String(key)
+		v := flagString(c, key)
 		if v != "" {
 			flags[val.(string)] = v
 		}
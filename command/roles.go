@@ -35,14 +35,14 @@ func rolesManager(c *cli.Context, set bool) {
 		Resource: rType,
 	}
 	if set {
-		client.Role().Create(role)
+		checkError(client.Role().Create(role))
 		verb := "own"
 		if c.String("role") == "reader" {
 			verb = "read"
 		}
 		color.Green(fmt.Sprintf(h.T("roles.set.success"), c.String("user"), verb, rID))
 	} else {
-		client.Role().Delete(role)
+		checkError(client.Role().Delete(role))
 		color.Green(fmt.Sprintf(h.T("roles.unset.success"), c.String("user"), rID, c.String("role")))
 	}
 
@@ -21,7 +21,8 @@ var EnvListSchedules = cli.Command{
 		paramsLenValidation(c, 2, "envs.schedule.list.args")
 		client := esetup(c, AuthUsersValidation)
 
-		env := client.Environment().Get(c.Args()[0], c.Args()[1])
+		env, err := client.Environment().Get(c.Args()[0], c.Args()[1])
+		checkError(err)
 		list := env.Schedules
 
 		view.PrintScheduleList(list)
@@ -46,7 +47,8 @@ var EnvAddSchedule = cli.Command{
 		paramsLenValidation(c, 3, "envs.schedule.list.args")
 		client := esetup(c, AuthUsersValidation)
 
-		env := client.Environment().Get(c.Args()[0], c.Args()[1])
+		env, err := client.Environment().Get(c.Args()[0], c.Args()[1])
+		checkError(err)
 
 		schedule := make(map[string]interface{}, 0)
 		schedule["name"] = c.Args()[2]
@@ -71,7 +73,7 @@ var EnvAddSchedule = cli.Command{
 		}
 
 		env.Schedules[c.Args()[2]] = schedule
-		client.Environment().Update(env)
+		checkError(client.Environment().Update(env))
 		color.Green(h.T("envs.schedule.add.success"))
 
 		return nil
@@ -89,10 +91,11 @@ var EnvRmSchedule = cli.Command{
 		paramsLenValidation(c, 2, "envs.schedule.list.args")
 		client := esetup(c, AuthUsersValidation)
 
-		env := client.Environment().Get(c.Args()[0], c.Args()[1])
+		env, err := client.Environment().Get(c.Args()[0], c.Args()[1])
+		checkError(err)
 		delete(env.Schedules, c.Args()[2])
 
-		client.Environment().Update(env)
+		checkError(client.Environment().Update(env))
 		color.Green(h.T("envs.schedule.rm.success"))
 
 		return nil
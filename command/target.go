@@ -5,14 +5,101 @@
 package command
 
 import (
+	"fmt"
 	"net/url"
 
 	h "github.com/ernestio/ernest-cli/helper"
 	"github.com/ernestio/ernest-cli/model"
+	"github.com/ernestio/ernest-cli/view"
 	"github.com/fatih/color"
 	"github.com/urfave/cli"
 )
 
+// AddTarget command
+// Configures a new named ernest target
+var AddTarget = cli.Command{
+	Name:        "add",
+	Usage:       h.T("target.add.usage"),
+	ArgsUsage:   h.T("target.add.args"),
+	Description: h.T("target.add.description"),
+	Flags: []cli.Flag{
+		tStringFlagND("target.add.flags.secret-store"),
+	},
+	Action: func(c *cli.Context) error {
+		paramsLenValidation(c, 2, "target.add.args")
+		setupGlobals(c)
+
+		cfg := &model.Config{
+			Name:        c.Args()[0],
+			URL:         c.Args()[1],
+			SecretStore: c.String("secret-store"),
+		}
+		if cfg.SecretStore == model.SecretStorePlain {
+			cfg.SecretStore = ""
+		}
+		if _, err := model.GetSecretStore(cfg.SecretStore); err != nil {
+			h.PrintErrorCode(err.Error(), h.ExitUsage)
+		}
+		if p := model.GetProfiles(); p != nil {
+			if _, ok := p.Profiles[cfg.Name]; ok {
+				h.PrintError(fmt.Sprintf(h.T("target.add.errors.exists"), cfg.Name))
+			}
+		}
+		persistTarget(cfg)
+
+		color.Green(fmt.Sprintf(h.T("target.add.success"), cfg.Name))
+		return nil
+	},
+}
+
+// UseTarget command
+// Switches the ernest target in use
+var UseTarget = cli.Command{
+	Name:        "use",
+	Usage:       h.T("target.use.usage"),
+	ArgsUsage:   h.T("target.use.args"),
+	Description: h.T("target.use.description"),
+	Action: func(c *cli.Context) error {
+		paramsLenValidation(c, 1, "target.use.args")
+		setupGlobals(c)
+
+		name := c.Args()[0]
+		p := model.GetProfiles()
+		if p == nil {
+			h.PrintError(fmt.Sprintf(h.T("target.use.errors.not_found"), name))
+		}
+		if _, ok := p.Profiles[name]; !ok {
+			h.PrintError(fmt.Sprintf(h.T("target.use.errors.not_found"), name))
+		}
+
+		p.Current = name
+		h.EvaluateErrorMsg(p.Save(), "Couldn't write config file ~/.ernest check permissions")
+
+		color.Green(fmt.Sprintf(h.T("target.use.success"), name))
+		return nil
+	},
+}
+
+// ListTargets command
+// Lists all configured ernest targets
+var ListTargets = cli.Command{
+	Name:        "list",
+	Usage:       h.T("target.list.usage"),
+	ArgsUsage:   h.T("target.list.args"),
+	Description: h.T("target.list.description"),
+	Action: func(c *cli.Context) error {
+		setupGlobals(c)
+
+		p := model.GetProfiles()
+		if p == nil {
+			p = &model.Profiles{}
+		}
+		view.PrintProfileList(p)
+
+		return nil
+	},
+}
+
 // Target command
 // Configures the ernest target instance
 var Target = cli.Command{
@@ -21,9 +108,19 @@ var Target = cli.Command{
 	Usage:       h.T("target.usage"),
 	ArgsUsage:   h.T("target.args"),
 	Description: h.T("target.description"),
+	Subcommands: []cli.Command{
+		AddTarget,
+		UseTarget,
+		ListTargets,
+	},
 	Action: func(c *cli.Context) error {
 		paramsLenValidation(c, 1, "target.args")
-		cfg := esetup(c, NoValidation).Config()
+		setupGlobals(c)
+
+		cfg := getConfig()
+		if cfg == nil {
+			cfg = &model.Config{}
+		}
 
 		cfg.URL = c.Args()[0]
 		persistTarget(cfg)
@@ -39,8 +136,7 @@ func persistTarget(cfg *model.Config) {
 		color.Yellow("Warning! Your are using an insecure target for Ernest")
 	}
 	if u.Scheme != "https" && u.Scheme != "http" {
-		color.Red("You should specify a valid url for the target")
-		return
+		h.PrintError("You should specify a valid url for the target")
 	}
 	err := model.SaveConfig(cfg)
 	if err != nil {
@@ -26,7 +26,8 @@ var CmdUsage = cli.Command{
 	},
 	Action: func(c *cli.Context) error {
 		client := esetup(c, AuthUsersValidation)
-		body := client.Report().Usage(c.String("from"), c.String("to"))
+		body, err := client.Report().Usage(c.String("from"), c.String("to"))
+		checkError(err)
 
 		if c.String("output") != "" {
 			if err := ioutil.WriteFile(c.String("output"), body, 0644); err != nil {
@@ -28,7 +28,8 @@ var ListUsers = cli.Command{
 	Description: h.T("user.list.description"),
 	Action: func(c *cli.Context) error {
 		client := esetup(c, AuthUsersValidation)
-		users := client.User().List()
+		users, err := client.User().List()
+		checkError(err)
 		view.PrintUserList(users)
 
 		return nil
@@ -59,7 +60,7 @@ var CreateUser = cli.Command{
 			MFA:      &mfa,
 			Disabled: h.Bool(false),
 		}
-		client.User().Create(user)
+		checkError(client.User().Create(user))
 		color.Green("User %s successfully created\n\n", usr)
 
 		if mfa {
@@ -68,7 +69,7 @@ var CreateUser = cli.Command{
 		}
 
 		if c.Bool("admin") {
-			client.User().Promote(user)
+			checkError(client.User().Promote(user))
 		}
 
 		return nil
@@ -87,11 +88,12 @@ var PasswordUser = cli.Command{
 	},
 	Action: func(c *cli.Context) error {
 		client := esetup(c, AuthUsersValidation)
-		session := client.Session().Get()
+		session, err := client.Session().Get()
+		checkError(err)
 
 		username := c.String("user")
-		password := c.String("password")
-		currentPassword := c.String("current-password")
+		password := flagString(c, "password")
+		currentPassword := flagString(c, "current-password")
 
 		if !session.IsAdmin() && username != "" {
 			h.PrintError("You don’t have permissions to perform this action")
@@ -102,16 +104,18 @@ var PasswordUser = cli.Command{
 			if password == "" {
 				h.PrintError("Please provide a valid password for the user with `--password`")
 			}
-			user := client.User().Get(username)
+			user, err := client.User().Get(username)
+			checkError(err)
 			user.Password = password
 			user.Disabled = h.Bool(false)
-			client.User().Update(user)
+			checkError(client.User().Update(user))
 			color.Green("`" + username + "` password has been changed")
 
 		} else {
 
 			// Ask the user for credentials
-			users := client.User().List()
+			users, err := client.User().List()
+			checkError(err)
 			if len(users) == 0 {
 				h.PrintError("You don’t have permissions to perform this action")
 			}
@@ -140,11 +144,12 @@ var PasswordUser = cli.Command{
 			}
 
 			username := client.Config().User
-			user := client.User().Get(username)
+			user, err := client.User().Get(username)
+			checkError(err)
 			user.Password = newpassword
 			user.OldPassword = oldpassword
 			user.Disabled = h.Bool(false)
-			client.User().Update(user)
+			checkError(client.User().Update(user))
 
 			color.Green("Your password has been changed")
 		}
@@ -164,10 +169,11 @@ var DisableUser = cli.Command{
 		client := esetup(c, NonAdminValidation)
 		username := c.Args()[0]
 
-		user := client.User().Get(username)
+		user, err := client.User().Get(username)
+		checkError(err)
 		user.Password = randString(16)
 		user.Disabled = h.Bool(true)
-		client.User().Update(user)
+		checkError(client.User().Update(user))
 
 		color.Green("Account `" + username + "` has been disabled")
 		return nil
@@ -186,7 +192,8 @@ var InfoUser = cli.Command{
 		client := esetup(c, NonAdminValidation)
 		username := stringWithDefault(c, "user", client.Config().User)
 
-		user := client.User().Get(username)
+		user, err := client.User().Get(username)
+		checkError(err)
 		view.PrintUserInfo(user)
 		return nil
 	},
@@ -204,9 +211,10 @@ var AddAdminUser = cli.Command{
 		client := esetup(c, NonAdminValidation)
 		username := c.Args()[0]
 
-		user := client.User().Get(username)
+		user, err := client.User().Get(username)
+		checkError(err)
 		user.Admin = true
-		client.User().Update(user)
+		checkError(client.User().Update(user))
 
 		color.Green("Admin privileges assigned to user " + username)
 		return nil
@@ -224,9 +232,10 @@ var RmAdminUser = cli.Command{
 		client := esetup(c, NonAdminValidation)
 		username := c.Args()[0]
 
-		user := client.User().Get(username)
+		user, err := client.User().Get(username)
+		checkError(err)
 		user.Admin = false
-		client.User().Update(user)
+		checkError(client.User().Update(user))
 
 		color.Green("Admin privileges revoked from user " + username)
 		return nil
@@ -243,16 +252,19 @@ var EnableMFA = cli.Command{
 	},
 	Action: func(c *cli.Context) error {
 		client := esetup(c, NonAdminValidation)
-		session := client.Session().Get()
+		session, err := client.Session().Get()
+		checkError(err)
 		username := stringWithDefault(c, "user-name", session.Username)
 
-		user := client.User().Get(username)
+		user, err := client.User().Get(username)
+		checkError(err)
 		if user.MFA != nil && *user.MFA {
 			fmt.Println("MFA already enabled")
 			return nil
 		}
 
-		secret := client.User().ToggleMFA(user, true)
+		secret, err := client.User().ToggleMFA(user, true)
+		checkError(err)
 		color.Green("MFA enabled")
 		fmt.Printf("Account name: Ernest (%s)\nKey: %s\n", user.Username, secret)
 
@@ -270,16 +282,19 @@ var DisableMFA = cli.Command{
 	},
 	Action: func(c *cli.Context) error {
 		client := esetup(c, NonAdminValidation)
-		session := client.Session().Get()
+		session, err := client.Session().Get()
+		checkError(err)
 		username := stringWithDefault(c, "user-name", session.Username)
 
-		user := client.User().Get(username)
+		user, err := client.User().Get(username)
+		checkError(err)
 		if user.MFA == nil || !*user.MFA {
 			fmt.Println("MFA already disabled")
 			return nil
 		}
 
-		_ = client.User().ToggleMFA(user, false)
+		_, err = client.User().ToggleMFA(user, false)
+		checkError(err)
 		color.Red("MFA disabled")
 
 		return nil
@@ -296,12 +311,16 @@ var ResetMFA = cli.Command{
 	},
 	Action: func(c *cli.Context) error {
 		client := esetup(c, NonAdminValidation)
-		session := client.Session().Get()
+		session, err := client.Session().Get()
+		checkError(err)
 		username := stringWithDefault(c, "user-name", session.Username)
-		user := client.User().Get(username)
+		user, err := client.User().Get(username)
+		checkError(err)
 
-		_ = client.User().ToggleMFA(user, false)
-		secret := client.User().ToggleMFA(user, true)
+		_, err = client.User().ToggleMFA(user, false)
+		checkError(err)
+		secret, err := client.User().ToggleMFA(user, true)
+		checkError(err)
 
 		color.Green("MFA reset")
 		fmt.Printf("Account name: Ernest (%s)\nKey: %s\n", user.Username, secret)
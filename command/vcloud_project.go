@@ -53,7 +53,7 @@ var CreateVcloudProject = cli.Command{
 			Type:        rtype,
 			Credentials: creds,
 		}
-		client.Project().Create(p)
+		checkError(client.Project().Create(p))
 		color.Green(fmt.Sprintf(h.T("vcloud.create.success"), p.Name))
 
 		return nil
@@ -71,7 +71,7 @@ var DeleteProject = cli.Command{
 		client := esetup(c, AuthUsersValidation)
 
 		name := c.Args()[0]
-		client.Project().Delete(name)
+		checkError(client.Project().Delete(name))
 		color.Green(fmt.Sprintf(h.T("vcloud.delete.success"), name))
 
 		return nil
@@ -100,10 +100,11 @@ var UpdateVCloudProject = cli.Command{
 		})
 		creds["user"] = creds["user"].(string) + "@" + creds["org"].(string)
 
-		n := client.Project().Get(c.Args()[0])
+		n, err := client.Project().Get(c.Args()[0])
+		checkError(err)
 		n.Credentials["user"] = creds["user"].(string)
 		n.Credentials["passwrord"] = creds["password"].(string)
-		client.Project().Update(n)
+		checkError(client.Project().Update(n))
 		color.Green(fmt.Sprintf(h.T("vcloud.update.success"), n.Name))
 
 		return nil
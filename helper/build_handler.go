@@ -6,15 +6,14 @@ package helper
 
 import (
 	"encoding/json"
-	"errors"
 	"fmt"
+	"time"
 
 	"github.com/ernestio/ernest-cli/model"
 	"github.com/gosuri/uilive"
 )
 
 type buildhandler struct {
-	stream    chan []byte
 	writer    *uilive.Writer
 	format    string
 	failures  []error
@@ -23,12 +22,14 @@ type buildhandler struct {
 	component model.ComponentEvent
 }
 
-func (h *buildhandler) subscribe() error {
+func (h *buildhandler) follow(stream chan []byte, deadline <-chan time.Time) error {
 	for {
 		select {
-		case msg, ok := <-h.stream:
+		case <-deadline:
+			return ErrMonitorTimeout
+		case msg, ok := <-stream:
 			if !ok {
-				return nil
+				return ErrStreamLost
 			}
 
 			if msg == nil {
@@ -42,7 +43,7 @@ func (h *buildhandler) subscribe() error {
 				return err
 			}
 
-			subject := m["_subject"].(string)
+			subject, _ := m["_subject"].(string)
 
 			switch subject {
 			case BUILDCREATE, BUILDDELETE, BUILDIMPORT:
@@ -74,9 +75,9 @@ func (h *buildhandler) subscribe() error {
 				return nil
 			case BUILDCREATEERROR, BUILDDELETEERROR, BUILDIMPORTERROR:
 				for _, resourceErr := range h.failures {
-					fmt.Printf("Message: %s\n\n", red(resourceErr))
+					fmt.Printf("Message: %s\n\n", red(MaskSecrets(resourceErr.Error())))
 				}
-				return errors.New("service task failed with errors")
+				return ErrBuildFailed
 			}
 
 		}
@@ -10,15 +10,50 @@ import (
 	"github.com/fatih/color"
 )
 
+// Exit codes returned by the cli, they are part of its public interface
+// and must not change between releases
+const (
+	// ExitSuccess : the command completed successfully
+	ExitSuccess = 0
+	// ExitFailure : generic failure
+	ExitFailure = 1
+	// ExitUsage : the command was invoked with wrong arguments or flags
+	ExitUsage = 2
+	// ExitUnauthorized : the user is not logged in, the credentials are not
+	// valid or the user lacks permissions
+	ExitUnauthorized = 3
+	// ExitValidation : the request was rejected by validations or policies
+	ExitValidation = 4
+	// ExitBuildErrored : the build finished with errors
+	ExitBuildErrored = 5
+	// ExitAwaiting : the build is awaiting approval or resolution
+	ExitAwaiting = 6
+	// ExitNotFound : the requested resource does not exist
+	ExitNotFound = 7
+	// ExitTimeout : the build didn't finish before the given timeout
+	ExitTimeout = 8
+)
+
 var Console = false
 
 // PrintError : prints an error and returns
 func PrintError(msg string) {
-	color.Red(msg)
+	PrintErrorCode(msg, ExitFailure)
+}
+
+// PrintErrorCode : prints an error and exits with the given code
+func PrintErrorCode(msg string, code int) {
+	color.Red(MaskSecrets(msg))
+	Exit(code)
+}
+
+// Exit : exits the program with the given code, on the console the
+// current command is interrupted instead
+func Exit(code int) {
 	if Console {
 		panic("console")
 	} else {
-		os.Exit(1)
+		os.Exit(code)
 	}
 }
 
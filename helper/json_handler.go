@@ -0,0 +1,63 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package helper
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// jsonhandler : prints every build and component event as a json record
+// per line
+type jsonhandler struct {
+	out io.Writer
+}
+
+func (h *jsonhandler) follow(stream chan []byte, deadline <-chan time.Time) error {
+	enc := json.NewEncoder(h.out)
+
+	for {
+		select {
+		case <-deadline:
+			return ErrMonitorTimeout
+		case msg, ok := <-stream:
+			if !ok {
+				return ErrStreamLost
+			}
+
+			if msg == nil {
+				continue
+			}
+
+			m := make(map[string]interface{})
+			if err := json.Unmarshal(msg, &m); err != nil {
+				return err
+			}
+
+			subject, _ := m["_subject"].(string)
+
+			var err error
+			switch subject {
+			case BUILDCREATE, BUILDDELETE, BUILDIMPORT,
+				BUILDCREATEDONE, BUILDDELETEDONE, BUILDIMPORTDONE,
+				BUILDCREATEERROR, BUILDDELETEERROR, BUILDIMPORTERROR:
+				err = enc.Encode(processBuildEvent(m))
+			default:
+				err = enc.Encode(processComponentEvent(m))
+			}
+			if err != nil {
+				return err
+			}
+
+			switch subject {
+			case BUILDCREATEDONE, BUILDDELETEDONE, BUILDIMPORTDONE:
+				return nil
+			case BUILDCREATEERROR, BUILDDELETEERROR, BUILDIMPORTERROR:
+				return ErrBuildFailed
+			}
+		}
+	}
+}
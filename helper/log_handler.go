@@ -33,7 +33,7 @@ func (h *loghandler) subscribe() error {
 			color.Yellow(m.Subject)
 			if len(m.Body) > 0 {
 				message, _ := prettyjson.Format([]byte(m.Body))
-				fmt.Println(string(message))
+				fmt.Println(MaskSecrets(string(message)))
 			} else {
 				fmt.Println("-- Empty string --")
 			}
@@ -5,6 +5,10 @@
 package helper
 
 import (
+	"errors"
+	"os"
+	"time"
+
 	"github.com/fatih/color"
 	"github.com/gosuri/uilive"
 )
@@ -30,23 +34,87 @@ const (
 	BUILDIMPORTERROR = "build.import.error"
 )
 
+const (
+	// ProgressTTY : animated table redrawn on every event
+	ProgressTTY = "tty"
+	// ProgressPlain : one timestamped line per transition
+	ProgressPlain = "plain"
+	// ProgressJSON : one json event record per line
+	ProgressJSON = "json"
+)
+
+// ErrBuildFailed : returned when a monitored build finishes with errors
+var ErrBuildFailed = errors.New("service task failed with errors")
+
+// ErrStreamLost : returned when a build stream is closed before the build
+// finishes
+var ErrStreamLost = errors.New("build stream was lost before the build finished")
+
+// ErrMonitorTimeout : returned when a build doesn't finish before the
+// monitor timeout
+var ErrMonitorTimeout = errors.New("timed out waiting for the build to finish")
+
 var (
 	green  = color.New(color.FgGreen).SprintFunc()
 	yellow = color.New(color.FgYellow).SprintFunc()
 	red    = color.New(color.FgRed).SprintFunc()
 )
 
-// Monitorize opens a websocket connection to get input messages
-func Monitorize(stream chan []byte) error {
-	h := buildhandler{
-		writer: uilive.New(),
-		stream: stream,
+// streamhandler : renders the events of a build stream
+type streamhandler interface {
+	follow(stream chan []byte, deadline <-chan time.Time) error
+}
+
+// Monitor : renders the progress of a build, keeping its state across
+// stream reconnections
+type Monitor struct {
+	handler streamhandler
+	writer  *uilive.Writer
+	expires time.Time
+}
+
+// NewMonitor : creates a monitor rendering on the given progress mode,
+// a zero timeout waits forever
+func NewMonitor(mode string, timeout time.Duration) *Monitor {
+	m := Monitor{}
+	if timeout > 0 {
+		m.expires = time.Now().Add(timeout)
 	}
 
-	h.writer.Start()
-	defer h.writer.Stop()
+	switch mode {
+	case ProgressPlain:
+		m.handler = &plainhandler{out: MaskWriter(os.Stdout)}
+	case ProgressJSON:
+		m.handler = &jsonhandler{out: MaskWriter(os.Stdout)}
+	default:
+		m.writer = uilive.New()
+		m.writer.Start()
+		m.handler = &buildhandler{writer: m.writer}
+	}
 
-	return h.subscribe()
+	return &m
+}
+
+// Follow : renders a build stream until the build finishes, failing with
+// ErrStreamLost if the stream is closed before
+func (m *Monitor) Follow(stream chan []byte) error {
+	var deadline <-chan time.Time
+	if !m.expires.IsZero() {
+		deadline = time.After(time.Until(m.expires))
+	}
+	return m.handler.follow(stream, deadline)
+}
+
+// Expired : checks if the monitor timeout has been reached
+func (m *Monitor) Expired() bool {
+	return !m.expires.IsZero() && time.Now().After(m.expires)
+}
+
+// Stop : stops rendering the build progress
+func (m *Monitor) Stop() {
+	if m.writer != nil {
+		m.writer.Stop()
+	}
 }
 
 // PrintLogs : prints logs inline
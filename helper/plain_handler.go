@@ -0,0 +1,77 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package helper
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// plainhandler : prints one line per build and component transition,
+// suitable for logs where the animated output can't be redrawn
+type plainhandler struct {
+	out io.Writer
+}
+
+func (h *plainhandler) follow(stream chan []byte, deadline <-chan time.Time) error {
+	for {
+		select {
+		case <-deadline:
+			return ErrMonitorTimeout
+		case msg, ok := <-stream:
+			if !ok {
+				return ErrStreamLost
+			}
+
+			if msg == nil {
+				continue
+			}
+
+			m := make(map[string]interface{})
+			if err := json.Unmarshal(msg, &m); err != nil {
+				return err
+			}
+
+			subject, _ := m["_subject"].(string)
+
+			switch subject {
+			case BUILDCREATE, BUILDDELETE, BUILDIMPORT:
+				b := processBuildEvent(m)
+				h.println("build %s %s started on %s, %d changes", b.ID, buildAction(subject), b.Name, len(b.Changes))
+			case BUILDCREATEDONE, BUILDDELETEDONE, BUILDIMPORTDONE:
+				b := processBuildEvent(m)
+				h.println("build %s %s done", b.ID, buildAction(subject))
+				return nil
+			case BUILDCREATEERROR, BUILDDELETEERROR, BUILDIMPORTERROR:
+				b := processBuildEvent(m)
+				h.println("build %s %s errored", b.ID, buildAction(subject))
+				return ErrBuildFailed
+			default:
+				c := processComponentEvent(m)
+				if c.State == "errored" {
+					h.println("%s %s %s %s: %s", c.Type, c.Name, c.Action, c.State, c.Error)
+				} else {
+					h.println("%s %s %s %s", c.Type, c.Name, c.Action, c.State)
+				}
+			}
+		}
+	}
+}
+
+func (h *plainhandler) println(format string, args ...interface{}) {
+	fmt.Fprintf(h.out, time.Now().Format(time.RFC3339)+" "+format+"\n", args...)
+}
+
+func buildAction(subject string) string {
+	switch subject {
+	case BUILDDELETE, BUILDDELETEDONE, BUILDDELETEERROR:
+		return "delete"
+	case BUILDIMPORT, BUILDIMPORTDONE, BUILDIMPORTERROR:
+		return "import"
+	}
+	return "apply"
+}
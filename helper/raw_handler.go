@@ -28,7 +28,7 @@ func (h *rawhandler) subscribe() error {
 				return err
 			}
 
-			fmt.Println("[" + m.Subject + "] : " + m.Body)
+			fmt.Println("[" + m.Subject + "] : " + MaskSecrets(m.Body))
 		}
 	}
 }
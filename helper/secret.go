@@ -0,0 +1,93 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package helper
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+// secretMask : replaces secrets on any output
+const secretMask = "********"
+
+// minMaskedSecret : shorter secrets are not masked, as they would hide
+// unrelated text
+const minMaskedSecret = 4
+
+// secrets : values read by ReadSecret, masked on the output
+var secrets []string
+
+// stdinRead : stdin holds a single secret, it can't be read twice
+var stdinRead bool
+
+// ReadSecret : resolves the value of a sensitive flag, given as @<file>,
+// as - to read it from stdin, as env:<VAR> or literally. Literal values
+// starting with @ are escaped as @@. The secret is masked on any output
+// from then on
+func ReadSecret(value string) (string, error) {
+	var secret string
+	switch {
+	case strings.HasPrefix(value, "@@"):
+		secret = value[1:]
+	case strings.HasPrefix(value, "@"):
+		body, err := ioutil.ReadFile(value[1:])
+		if err != nil {
+			return "", errors.New("Can't read secret file " + value[1:])
+		}
+		secret = strings.TrimRight(string(body), "\r\n")
+	case value == "-":
+		if stdinRead {
+			return "", errors.New("Only one secret can be read from stdin")
+		}
+		stdinRead = true
+		body, err := ioutil.ReadAll(os.Stdin)
+		if err != nil {
+			return "", errors.New("Can't read secret from stdin")
+		}
+		secret = strings.TrimRight(string(body), "\r\n")
+	case strings.HasPrefix(value, "env:"):
+		var ok bool
+		if secret, ok = os.LookupEnv(value[4:]); !ok {
+			return "", errors.New("Environment variable " + value[4:] + " is not set")
+		}
+	default:
+		secret = value
+	}
+
+	if len(secret) >= minMaskedSecret {
+		secrets = append(secrets, secret)
+	}
+
+	return secret, nil
+}
+
+// MaskSecrets : hides the secrets read by ReadSecret on a message
+func MaskSecrets(msg string) string {
+	for _, s := range secrets {
+		msg = strings.Replace(msg, s, secretMask, -1)
+	}
+	return msg
+}
+
+// maskWriter : masks secrets on everything written to the wrapped writer
+type maskWriter struct {
+	w io.Writer
+}
+
+// MaskWriter : wraps a writer so the secrets read by ReadSecret are masked
+// on what is written to it
+func MaskWriter(w io.Writer) io.Writer {
+	return &maskWriter{w: w}
+}
+
+func (m *maskWriter) Write(p []byte) (int, error) {
+	if _, err := io.WriteString(m.w, MaskSecrets(string(p))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -0,0 +1,133 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package helper
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func resetSecrets() {
+	secrets = nil
+	stdinRead = false
+}
+
+func TestReadSecret(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ernest-secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "secret")
+	if err := ioutil.WriteFile(file, []byte("from-file\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	os.Setenv("ERNEST_TEST_SECRET", "from-env")
+	defer os.Unsetenv("ERNEST_TEST_SECRET")
+
+	tests := []struct {
+		value string
+		want  string
+		err   string
+	}{
+		{"literal", "literal", ""},
+		{"@@literal", "@literal", ""},
+		{"@" + file, "from-file", ""},
+		{"@" + filepath.Join(dir, "missing"), "", "Can't read secret file " + filepath.Join(dir, "missing")},
+		{"env:ERNEST_TEST_SECRET", "from-env", ""},
+		{"env:ERNEST_TEST_MISSING", "", "Environment variable ERNEST_TEST_MISSING is not set"},
+	}
+
+	for _, tt := range tests {
+		resetSecrets()
+		got, err := ReadSecret(tt.value)
+		if tt.err != "" {
+			if err == nil || err.Error() != tt.err {
+				t.Errorf("ReadSecret(%q): expected error %q, got %v", tt.value, tt.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ReadSecret(%q): unexpected error %v", tt.value, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ReadSecret(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestReadSecretStdin(t *testing.T) {
+	resetSecrets()
+	defer resetSecrets()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdin := os.Stdin
+	os.Stdin = r
+	defer func() { os.Stdin = stdin }()
+
+	_, _ = w.WriteString("from-stdin\r\n")
+	_ = w.Close()
+
+	got, err := ReadSecret("-")
+	if err != nil || got != "from-stdin" {
+		t.Fatalf("ReadSecret(-) = %q, %v", got, err)
+	}
+	if _, err := ReadSecret("-"); err == nil {
+		t.Error("expected an error reading stdin twice")
+	}
+}
+
+func TestMaskSecrets(t *testing.T) {
+	tests := []struct {
+		secrets []string
+		msg     string
+		want    string
+	}{
+		{nil, "password is hunter2", "password is hunter2"},
+		{[]string{"hunter2"}, "password is hunter2", "password is ********"},
+		{[]string{"hunter2"}, "hunter2 and hunter2", "******** and ********"},
+		{[]string{"abc"}, "abc is too short to mask", "abc is too short to mask"},
+		{[]string{"key1", "secret2"}, "key1:secret2", "********:********"},
+	}
+
+	for _, tt := range tests {
+		resetSecrets()
+		for _, s := range tt.secrets {
+			if _, err := ReadSecret(s); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if got := MaskSecrets(tt.msg); got != tt.want {
+			t.Errorf("MaskSecrets(%q) = %q, want %q", tt.msg, got, tt.want)
+		}
+	}
+	resetSecrets()
+}
+
+func TestMaskWriter(t *testing.T) {
+	resetSecrets()
+	defer resetSecrets()
+	if _, err := ReadSecret("hunter2"); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	w := MaskWriter(&buf)
+	n, err := w.Write([]byte("token: hunter2\n"))
+	if err != nil || n != len("token: hunter2\n") {
+		t.Fatalf("Write = %d, %v", n, err)
+	}
+	if got := buf.String(); got != "token: ********\n" {
+		t.Errorf("got %q", got)
+	}
+}
@@ -0,0 +1,51 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package helper
+
+import (
+	"testing"
+	"time"
+)
+
+// rfc6238Secret : base32 encoding of the RFC 6238 SHA1 test key
+// "12345678901234567890"
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTP(t *testing.T) {
+	// RFC 6238 appendix B SHA1 vectors, truncated to six digits
+	tests := []struct {
+		secret string
+		unix   int64
+		want   string
+	}{
+		{rfc6238Secret, 59, "287082"},
+		{rfc6238Secret, 1111111109, "081804"},
+		{rfc6238Secret, 1111111111, "050471"},
+		{rfc6238Secret, 1234567890, "005924"},
+		{rfc6238Secret, 2000000000, "279037"},
+		{rfc6238Secret, 20000000000, "353130"},
+		{"gezd gnbv gy3t qojq gezd gnbv gy3t qojq", 59, "287082"},
+		{rfc6238Secret + "====", 59, "287082"},
+	}
+
+	for _, tt := range tests {
+		got, err := TOTP(tt.secret, time.Unix(tt.unix, 0))
+		if err != nil {
+			t.Errorf("TOTP(%q, %d): unexpected error %v", tt.secret, tt.unix, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("TOTP(%q, %d) = %s, want %s", tt.secret, tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestTOTPInvalidSecret(t *testing.T) {
+	for _, secret := range []string{"", "not base32!", "1"} {
+		if _, err := TOTP(secret, time.Unix(59, 0)); err == nil {
+			t.Errorf("TOTP(%q): expected an error", secret)
+		}
+	}
+}
@@ -27,8 +27,10 @@ var CmdConsole = cli.Command{
 	Usage:       "Interactive ernest shell",
 	ArgsUsage:   "console",
 	Description: "Interactive ernest shell",
+	Flags:       command.MFAFlags,
 	Action: func(ctx *cli.Context) error {
 		client := command.Esetup(ctx, command.AuthUsersValidation)
+		command.SetupMFA(ctx)
 
 		// TODO force login if is not logged in
 		userChain = append(userChain, client.Config())
@@ -198,9 +200,10 @@ func login(c *ishell.Context) (*model.Config, error) {
 	c.Print("Password for " + username + ": ")
 	password := c.ReadPassword()
 
-	client := command.Elogin(username, password, "")
-
-	token, err := client.Cli().Authenticate()
+	client, token, err := command.Eauthenticate(username, password, "", func() (string, error) {
+		c.Print("Verification code: ")
+		return c.ReadPassword(), nil
+	})
 	if err != nil {
 		return nil, err
 	}
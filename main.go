@@ -5,10 +5,11 @@
 package main
 
 import (
-	"log"
+	"fmt"
 	"os"
 
 	"github.com/ernestio/ernest-cli/command"
+	h "github.com/ernestio/ernest-cli/helper"
 	"github.com/ernestio/ernest-cli/icommand"
 	"github.com/urfave/cli"
 )
@@ -22,7 +23,11 @@ func main() {
 	app.Version = Version
 	app.Usage = "Command line interface for Ernest"
 	app.EnableBashCompletion = true
-	app.Commands = []cli.Command{
+	app.Flags = command.GlobalFlags
+	app.CommandNotFound = func(c *cli.Context, name string) {
+		h.PrintErrorCode(fmt.Sprintf("Unknown command '%s', run 'ernest help' to list the available commands", name), h.ExitUsage)
+	}
+	app.Commands = command.WithGlobalFlags([]cli.Command{
 		command.Target,
 		command.Info,
 		command.Login,
@@ -30,7 +35,10 @@ func main() {
 		command.CmdUser,
 		command.CmdProject,
 		command.CmdEnv,
+		command.CmdDrift,
 		command.CmdPreferences,
+		command.CmdConfig,
+		command.CmdCredentials,
 		command.CmdDocs,
 		command.CmdLog,
 		command.CmdUsage,
@@ -38,8 +46,10 @@ func main() {
 		command.CmdPolicy,
 		command.CmdRoles,
 		icommand.CmdConsole,
-	}
+	})
+	// commands exit on their own failures, errors reaching this point
+	// come from parsing the arguments and flags
 	if err := app.Run(os.Args); err != nil {
-		log.Println("Oops, something is broken")
+		os.Exit(h.ExitUsage)
 	}
 }
@@ -0,0 +1,108 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package manager
+
+import (
+	"os"
+	"sync"
+
+	"github.com/ernestio/ernest-cli/model"
+
+	eclient "github.com/ernestio/ernest-go-sdk/client"
+	econfig "github.com/ernestio/ernest-go-sdk/config"
+)
+
+// auth : session shared by the wrappers of a client, renewing its token
+// when requests are rejected as unauthorized
+type auth struct {
+	cli *eclient.Client
+	cfg *model.Config
+	// mu is held for reading by requests and for writing while the sdk
+	// client is replaced by a renewal
+	mu sync.RWMutex
+	// session counts the renewals, so concurrent requests rejected with
+	// the same token renew it only once
+	session int
+	renewed bool
+}
+
+// do : runs a request, retrying it once with a renewed token if it is
+// rejected as unauthorized. Permission denials are returned as they are,
+// as a new token would be denied too
+func (a *auth) do(request func() error) error {
+	if a == nil {
+		return wrap(request())
+	}
+
+	session, err := a.request(request)
+	if Kind(err) != ErrUnauthorized {
+		return err
+	}
+	if a.renew(session) != nil {
+		return err
+	}
+	_, err = a.request(request)
+	return err
+}
+
+// request : runs a request with the current session, returning it along
+// with the request error
+func (a *auth) request(request func() error) (int, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.session, wrap(request())
+}
+
+// renew : authenticates again with the credentials given on the
+// ERNEST_USER and ERNEST_PASSWORD environment variables, the user
+// falling back to the one logged in. The session is renewed once per
+// client, and the new token is stored on its profile. Nothing is done if
+// the given session has already been renewed
+func (a *auth) renew(session int) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.session != session {
+		return nil
+	}
+
+	user := os.Getenv("ERNEST_USER")
+	if user == "" {
+		user = a.cfg.User
+	}
+	password := os.Getenv("ERNEST_PASSWORD")
+	if a.renewed || user == "" || password == "" {
+		return NewError(ErrUnauthorized, "No credentials available to renew the session")
+	}
+	a.renewed = true
+
+	token, err := eclient.New(
+		econfig.New(a.cfg.URL).WithCredentials(user, password),
+	).Authenticate()
+	if err != nil {
+		return wrap(err)
+	}
+
+	a.session++
+	a.cfg.User = user
+	a.cfg.Token = token
+	// wrappers share the sdk client, replacing it in place makes all of
+	// them use the new token
+	*a.cli = *eclient.New(econfig.New(a.cfg.URL).WithToken(token))
+
+	// the renewed token is used by the running command even if it can't
+	// be stored
+	_ = model.SaveConfig(a.cfg)
+
+	return nil
+}
+
+// Renew : authenticates again with the credentials on the environment,
+// used when the stored token has expired
+func (c *Client) Renew() error {
+	c.auth.mu.RLock()
+	session := c.auth.session
+	c.auth.mu.RUnlock()
+	return c.auth.renew(session)
+}
@@ -0,0 +1,70 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package manager
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/ernestio/ernest-cli/model"
+
+	eclient "github.com/ernestio/ernest-go-sdk/client"
+)
+
+func TestAuthRenew(t *testing.T) {
+	home, err := ioutil.TempDir("", "ernest-home")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(home)
+	defer os.Setenv("HOME", os.Getenv("HOME"))
+	os.Setenv("HOME", home)
+	defer os.Unsetenv("ERNEST_PASSWORD")
+	os.Setenv("ERNEST_PASSWORD", "secret")
+
+	tests := []struct {
+		name  string
+		err   error
+		renew bool
+	}{
+		{"unauthorized requests renew the token", &statusError{401, "Unauthorized"}, true},
+		{"expired tokens renew the token", errors.New("Token is expired"), true},
+		{"forbidden requests do not renew the token", &statusError{403, "Forbidden"}, false},
+		{"permission denials do not renew the token", errors.New("You don’t have permissions to perform this action"), false},
+		{"other errors do not renew the token", &statusError{404, "Not found"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &auth{
+				cli: &eclient.Client{},
+				cfg: &model.Config{URL: "http://127.0.0.1:1", User: "admin", Token: "old"},
+			}
+
+			calls := 0
+			err := a.do(func() error {
+				calls++
+				if calls == 1 {
+					return tt.err
+				}
+				return nil
+			})
+
+			if a.renewed != tt.renew {
+				t.Fatalf("renewed = %t, want %t", a.renewed, tt.renew)
+			}
+			if !tt.renew {
+				if calls != 1 {
+					t.Errorf("request was sent %d times, want 1", calls)
+				}
+				if err == nil || err.Error() != tt.err.Error() {
+					t.Errorf("got error %v, want %v", err, tt.err)
+				}
+			}
+		})
+	}
+}
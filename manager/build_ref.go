@@ -0,0 +1,104 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package manager
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	emodels "github.com/ernestio/ernest-go-sdk/models"
+)
+
+const (
+	// BuildLatest : references the newest build of an environment
+	BuildLatest = "latest"
+	// BuildLastSuccessful : references the newest build which is done
+	BuildLastSuccessful = "last-successful"
+)
+
+// Resolve : finds a build of an environment by its reference, which can
+// also be a tag on the profile config. See ResolveBuild for the rest of
+// accepted references
+func (c *Build) Resolve(project, env, ref string) (*emodels.Build, error) {
+	builds, err := c.List(project, env)
+	if err != nil {
+		return nil, err
+	}
+	if c.cfg != nil {
+		if tag, ok := c.cfg.FindTag(project, env, ref); ok {
+			ref = tag.Build
+		}
+	}
+	return ResolveBuild(builds, ref)
+}
+
+// ResolveBuild : finds a build on a list ordered newest first. The reference
+// can be empty or latest for the newest build, latest~N for the Nth build
+// before it, last-successful, a position counted from the oldest build
+// starting on 1, or a full or prefix build id. Numbers matching both a
+// position and the id of another build are ambiguous
+func ResolveBuild(builds []*emodels.Build, ref string) (*emodels.Build, error) {
+	if len(builds) == 0 {
+		return nil, NewError(ErrNotFound, "No builds were found for the specified parameters")
+	}
+
+	ref = strings.TrimSpace(ref)
+
+	switch {
+	case ref == "" || ref == BuildLatest:
+		return builds[0], nil
+	case strings.HasPrefix(ref, BuildLatest+"~"):
+		n, err := strconv.Atoi(strings.TrimPrefix(ref, BuildLatest+"~"))
+		if err != nil || n < 0 {
+			return nil, NewError(ErrUsage, "Invalid build reference '"+ref+"', it should be as latest~2")
+		}
+		if n >= len(builds) {
+			return nil, NewError(ErrNotFound, fmt.Sprintf("Build '%s' does not exist, the environment has %d builds", ref, len(builds)))
+		}
+		return builds[n], nil
+	case ref == BuildLastSuccessful:
+		for _, b := range builds {
+			if b.Status == "done" {
+				return b, nil
+			}
+		}
+		return nil, NewError(ErrNotFound, "The environment has no successful builds")
+	}
+
+	var position *emodels.Build
+	if pos, err := strconv.Atoi(ref); err == nil && pos >= 1 && pos <= len(builds) {
+		position = builds[len(builds)-pos]
+	}
+
+	var matches []*emodels.Build
+	for _, b := range builds {
+		if b.ID == ref {
+			matches = []*emodels.Build{b}
+			break
+		}
+		if strings.HasPrefix(b.ID, ref) {
+			matches = append(matches, b)
+		}
+	}
+
+	// numbers may be a position or an id prefix, they only resolve when
+	// both point to the same build
+	if position != nil {
+		if len(matches) == 0 || len(matches) == 1 && matches[0] == position {
+			return position, nil
+		}
+		return nil, NewError(ErrUsage, "Build '"+ref+"' is ambiguous, it is a build position and a build id prefix")
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, NewError(ErrNotFound, "Build '"+ref+"' does not exist")
+	case 1:
+		return matches[0], nil
+	}
+
+	return nil, NewError(ErrUsage, fmt.Sprintf("Build '%s' is ambiguous, it matches %d builds", ref, len(matches)))
+}
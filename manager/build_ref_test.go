@@ -0,0 +1,124 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package manager
+
+import (
+	"testing"
+
+	emodels "github.com/ernestio/ernest-go-sdk/models"
+)
+
+func TestResolveBuild(t *testing.T) {
+	// newest first, as returned by the api
+	builds := []*emodels.Build{
+		{ID: "d4e5f6a7", Status: "errored"},
+		{ID: "c3d4e5f6", Status: "done"},
+		{ID: "abc12345", Status: "done"},
+		{ID: "abd67890", Status: "done"},
+	}
+
+	tests := []struct {
+		ref  string
+		want string
+		kind ErrorKind
+		err  string
+	}{
+		{"", "d4e5f6a7", "", ""},
+		{"latest", "d4e5f6a7", "", ""},
+		{" latest ", "d4e5f6a7", "", ""},
+		{"latest~0", "d4e5f6a7", "", ""},
+		{"latest~1", "c3d4e5f6", "", ""},
+		{"latest~3", "abd67890", "", ""},
+		{"latest~4", "", ErrNotFound, "Build 'latest~4' does not exist, the environment has 4 builds"},
+		{"latest~x", "", ErrUsage, "Invalid build reference 'latest~x', it should be as latest~2"},
+		{"latest~-1", "", ErrUsage, "Invalid build reference 'latest~-1', it should be as latest~2"},
+		{"last-successful", "c3d4e5f6", "", ""},
+		{"1", "abd67890", "", ""},
+		{"4", "d4e5f6a7", "", ""},
+		{"c3d4e5f6", "c3d4e5f6", "", ""},
+		{"c3", "c3d4e5f6", "", ""},
+		{"ab", "", ErrUsage, "Build 'ab' is ambiguous, it matches 2 builds"},
+		{"abc", "abc12345", "", ""},
+		{"ffff", "", ErrNotFound, "Build 'ffff' does not exist"},
+	}
+
+	for _, tt := range tests {
+		b, err := ResolveBuild(builds, tt.ref)
+		if tt.err != "" {
+			if err == nil || err.Error() != tt.err || Kind(err) != tt.kind {
+				t.Errorf("ResolveBuild(%q): expected %s error %q, got %v", tt.ref, tt.kind, tt.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ResolveBuild(%q): unexpected error %v", tt.ref, err)
+			continue
+		}
+		if b.ID != tt.want {
+			t.Errorf("ResolveBuild(%q) = %s, want %s", tt.ref, b.ID, tt.want)
+		}
+	}
+}
+
+func TestResolveBuildNumbers(t *testing.T) {
+	// ids starting with digits can clash with build positions
+	builds := []*emodels.Build{
+		{ID: "3f0a1b2c", Status: "done"},
+		{ID: "1e2d3c4b", Status: "done"},
+		{ID: "2a9b8c7d", Status: "done"},
+		{ID: "12ab34cd", Status: "done"},
+	}
+
+	tests := []struct {
+		ref  string
+		want string
+		err  string
+	}{
+		{"4", "3f0a1b2c", ""},
+		{"3", "", "Build '3' is ambiguous, it is a build position and a build id prefix"},
+		{"2", "2a9b8c7d", ""},
+		{"1", "", "Build '1' is ambiguous, it is a build position and a build id prefix"},
+		{"12", "12ab34cd", ""},
+		{"1e", "1e2d3c4b", ""},
+	}
+
+	for _, tt := range tests {
+		b, err := ResolveBuild(builds, tt.ref)
+		if tt.err != "" {
+			if err == nil || err.Error() != tt.err || Kind(err) != ErrUsage {
+				t.Errorf("ResolveBuild(%q): expected usage error %q, got %v", tt.ref, tt.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ResolveBuild(%q): unexpected error %v", tt.ref, err)
+			continue
+		}
+		if b.ID != tt.want {
+			t.Errorf("ResolveBuild(%q) = %s, want %s", tt.ref, b.ID, tt.want)
+		}
+	}
+}
+
+func TestResolveBuildEdgeCases(t *testing.T) {
+	tests := []struct {
+		name   string
+		builds []*emodels.Build
+		ref    string
+		err    string
+	}{
+		{"no builds", nil, "latest", "No builds were found for the specified parameters"},
+		{"no successful builds", []*emodels.Build{{ID: "a", Status: "errored"}}, "last-successful", "The environment has no successful builds"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ResolveBuild(tt.builds, tt.ref)
+			if err == nil || err.Error() != tt.err {
+				t.Errorf("expected error %q, got %v", tt.err, err)
+			}
+		})
+	}
+}
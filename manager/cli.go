@@ -11,6 +11,7 @@ import (
 type Client struct {
 	cli          *eclient.Client
 	cfg          *model.Config
+	auth         *auth
 	user         *User
 	session      *Session
 	notification *Notification
@@ -28,7 +29,7 @@ func NewFromCreds(config *model.Config) *Client {
 	client := eclient.New(
 		econfig.New(config.URL).WithCredentials(config.User, config.Password),
 	)
-	return &Client{cli: client, cfg: config}
+	return newClient(client, config)
 }
 
 // NewFromCredsAndVerification ...
@@ -37,7 +38,7 @@ func NewFromCredsAndVerification(config *model.Config) *Client {
 		econfig.New(config.URL).
 			WithCredentialsAndVerification(config.User, config.Password, config.Verification),
 	)
-	return &Client{cli: client, cfg: config}
+	return newClient(client, config)
 }
 
 // New : ...
@@ -45,86 +46,77 @@ func New(config *model.Config) *Client {
 	client := eclient.New(
 		econfig.New(config.URL).WithToken(config.Token),
 	)
-	return &Client{cli: client, cfg: config}
+	return newClient(client, config)
+}
+
+// newClient : creates the wrappers of a client up front, so a client can
+// be shared by concurrent requests
+func newClient(client *eclient.Client, config *model.Config) *Client {
+	a := &auth{cli: client, cfg: config}
+	return &Client{
+		cli:          client,
+		cfg:          config,
+		auth:         a,
+		user:         &User{cli: client, auth: a},
+		session:      &Session{cli: client, auth: a},
+		notification: &Notification{cli: client, auth: a},
+		policy:       &Policy{cli: client, auth: a},
+		role:         &Role{cli: client, auth: a},
+		project:      &Project{cli: client, auth: a},
+		env:          &Environment{cli: client, auth: a},
+		build:        &Build{cli: client, auth: a, cfg: config},
+		logger:       &Logger{cli: client, auth: a},
+		report:       &Report{cli: client, auth: a},
+	}
 }
 
-// User : User wrapper lazy load
+// User : User wrapper
 func (c *Client) User() *User {
-	if c.user == nil {
-		c.user = &User{cli: c.cli}
-	}
 	return c.user
 }
 
-// Session : Session wrapper lazy load
+// Session : Session wrapper
 func (c *Client) Session() *Session {
-	if c.session == nil {
-		c.session = &Session{cli: c.cli}
-	}
 	return c.session
 }
 
-// Notification : Notification wrapper lazy load
+// Notification : Notification wrapper
 func (c *Client) Notification() *Notification {
-	if c.notification == nil {
-		c.notification = &Notification{cli: c.cli}
-	}
 	return c.notification
 }
 
-// Policy : Policy wrapper lazy load
+// Policy : Policy wrapper
 func (c *Client) Policy() *Policy {
-	if c.notification == nil {
-		c.policy = &Policy{cli: c.cli}
-	}
 	return c.policy
 }
 
-// Role : Role wrapper lazy load
+// Role : Role wrapper
 func (c *Client) Role() *Role {
-	if c.role == nil {
-		c.role = &Role{cli: c.cli}
-	}
 	return c.role
 }
 
-// Project : Project wrapper lazy load
+// Project : Project wrapper
 func (c *Client) Project() *Project {
-	if c.project == nil {
-		c.project = &Project{cli: c.cli}
-	}
 	return c.project
 }
 
-// Environment : Environment wrapper lazy load
+// Environment : Environment wrapper
 func (c *Client) Environment() *Environment {
-	if c.env == nil {
-		c.env = &Environment{cli: c.cli}
-	}
 	return c.env
 }
 
-// Build : Build wrapper lazy load
+// Build : Build wrapper
 func (c *Client) Build() *Build {
-	if c.build == nil {
-		c.build = &Build{cli: c.cli}
-	}
 	return c.build
 }
 
-// Logger : Logger wrapper lazy load
+// Logger : Logger wrapper
 func (c *Client) Logger() *Logger {
-	if c.logger == nil {
-		c.logger = &Logger{cli: c.cli}
-	}
 	return c.logger
 }
 
-// Report : Report wrapper lazy load
+// Report : Report wrapper
 func (c *Client) Report() *Report {
-	if c.report == nil {
-		c.report = &Report{cli: c.cli}
-	}
 	return c.report
 }
 
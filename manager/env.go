@@ -5,116 +5,126 @@
 package manager
 
 import (
-	h "github.com/ernestio/ernest-cli/helper"
 	eclient "github.com/ernestio/ernest-go-sdk/client"
 	emodels "github.com/ernestio/ernest-go-sdk/models"
 )
 
 // Environment : ernest-go-sdk Environment wrapper
 type Environment struct {
-	cli *eclient.Client
+	cli  *eclient.Client
+	auth *auth
 }
 
 // Create : ...
-func (c *Environment) Create(project string, env *emodels.Environment) {
-	if err := c.cli.Environments.Create(project, env); err != nil {
-		h.PrintError(err.Error())
-	}
+func (c *Environment) Create(project string, env *emodels.Environment) error {
+	return c.auth.do(func() error {
+		return c.cli.Environments.Create(project, env)
+	})
 }
 
 // Delete : Deletes a env and all its relations
-func (c *Environment) Delete(project, env string) *emodels.Build {
-	build, err := c.cli.Environments.Delete(project, env)
-	if err != nil {
-		h.PrintError(err.Error())
-	}
-	return build
+func (c *Environment) Delete(project, env string) (*emodels.Build, error) {
+	var build *emodels.Build
+	err := c.auth.do(func() (err error) {
+		build, err = c.cli.Environments.Delete(project, env)
+		return err
+	})
+	return build, err
 }
 
 // ForceDeletion : Deletes a env and all its relations
-func (c *Environment) ForceDeletion(project, env string) *emodels.Build {
-	build, err := c.cli.Environments.ForceDeletion(project, env)
-	if err != nil {
-		h.PrintError(err.Error())
-	}
-	return build
+func (c *Environment) ForceDeletion(project, env string) (*emodels.Build, error) {
+	var build *emodels.Build
+	err := c.auth.do(func() (err error) {
+		build, err = c.cli.Environments.ForceDeletion(project, env)
+		return err
+	})
+	return build, err
 }
 
 // Get : Gets a env by name
-func (c *Environment) Get(project, id string) *emodels.Environment {
-	env, err := c.cli.Environments.Get(project, id)
-	if err != nil {
-		h.PrintError(err.Error())
-	}
-	return env
+func (c *Environment) Get(project, id string) (*emodels.Environment, error) {
+	var env *emodels.Environment
+	err := c.auth.do(func() (err error) {
+		env, err = c.cli.Environments.Get(project, id)
+		return err
+	})
+	return env, err
 }
 
 // Sync : Syncs a env by name
-func (c *Environment) Sync(project, id string) *emodels.Action {
-	act, err := c.cli.Environments.Sync(project, id)
-	if err != nil {
-		h.PrintError(err.Error())
-	}
-	return act
+func (c *Environment) Sync(project, id string) (*emodels.Action, error) {
+	var act *emodels.Action
+	err := c.auth.do(func() (err error) {
+		act, err = c.cli.Environments.Sync(project, id)
+		return err
+	})
+	return act, err
 }
 
 // Resolve : Resolves a env by name
-func (c *Environment) Resolve(project, id, resolution string) *emodels.Action {
-	act, err := c.cli.Environments.Resolve(project, id, resolution)
-	if err != nil {
-		h.PrintError(err.Error())
-	}
-	return act
+func (c *Environment) Resolve(project, id, resolution string) (*emodels.Action, error) {
+	var act *emodels.Action
+	err := c.auth.do(func() (err error) {
+		act, err = c.cli.Environments.Resolve(project, id, resolution)
+		return err
+	})
+	return act, err
 }
 
 // Review : Reviews an env by name
-func (c *Environment) Review(project, id, resolution string) *emodels.Action {
-	act, err := c.cli.Environments.Review(project, id, resolution)
-	if err != nil {
-		h.PrintError(err.Error())
-	}
-	return act
+func (c *Environment) Review(project, id, resolution string) (*emodels.Action, error) {
+	var act *emodels.Action
+	err := c.auth.do(func() (err error) {
+		act, err = c.cli.Environments.Review(project, id, resolution)
+		return err
+	})
+	return act, err
 }
 
 // Reset : Resets a env by name
-func (c *Environment) Reset(project, id string) *emodels.Action {
-	act, err := c.cli.Environments.Reset(project, id)
-	if err != nil {
-		h.PrintError(err.Error())
-	}
-	return act
+func (c *Environment) Reset(project, id string) (*emodels.Action, error) {
+	var act *emodels.Action
+	err := c.auth.do(func() (err error) {
+		act, err = c.cli.Environments.Reset(project, id)
+		return err
+	})
+	return act, err
 }
 
 // Validate : Validate a env by name
-func (c *Environment) Validate(project, env string) *emodels.Validation {
-	validation, err := c.cli.Environments.Validate(project, env)
-	if err != nil {
-		h.PrintError(err.Error())
-	}
-	return validation
+func (c *Environment) Validate(project, env string) (*emodels.Validation, error) {
+	var validation *emodels.Validation
+	err := c.auth.do(func() (err error) {
+		validation, err = c.cli.Environments.Validate(project, env)
+		return err
+	})
+	return validation, err
 }
 
 // Update : Updates a notification
-func (c *Environment) Update(env *emodels.Environment) {
-	if err := c.cli.Environments.Update(env); err != nil {
-		h.PrintError(err.Error())
-	}
+func (c *Environment) Update(env *emodels.Environment) error {
+	return c.auth.do(func() error {
+		return c.cli.Environments.Update(env)
+	})
 }
 
 // ListAll : Lists all envs on the system
-func (c *Environment) ListAll() []*emodels.Environment {
-	envs, err := c.cli.Environments.ListAll()
-	if err != nil {
-		h.PrintError(err.Error())
-	}
-	return envs
+func (c *Environment) ListAll() ([]*emodels.Environment, error) {
+	var envs []*emodels.Environment
+	err := c.auth.do(func() (err error) {
+		envs, err = c.cli.Environments.ListAll()
+		return err
+	})
+	return envs, err
 }
 
 // Import : creates an import build for an environment
-func (c *Environment) Import(project, env string, filters []string) *emodels.Action {
-	action, err := c.cli.Environments.Import(project, env, filters)
-	if err != nil {
-		h.PrintError(err.Error())
-	}
-	return action
+func (c *Environment) Import(project, env string, filters []string) (*emodels.Action, error) {
+	var action *emodels.Action
+	err := c.auth.do(func() (err error) {
+		action, err = c.cli.Environments.Import(project, env, filters)
+		return err
+	})
+	return action, err
 }
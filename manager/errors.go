@@ -0,0 +1,155 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package manager
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	emodels "github.com/ernestio/ernest-go-sdk/models"
+)
+
+// ErrorKind : classifies the errors returned by the manager
+type ErrorKind string
+
+const (
+	// ErrUnknown : the error could not be classified
+	ErrUnknown ErrorKind = "unknown"
+	// ErrNotFound : the requested resource does not exist
+	ErrNotFound ErrorKind = "not_found"
+	// ErrUnauthorized : the user is not logged in or its token is no
+	// longer valid
+	ErrUnauthorized ErrorKind = "unauthorized"
+	// ErrForbidden : the user is logged in, but lacks permissions
+	ErrForbidden ErrorKind = "forbidden"
+	// ErrValidation : the request was rejected by validations or policies
+	ErrValidation ErrorKind = "validation_failed"
+	// ErrConflict : the request clashes with the resource current state
+	ErrConflict ErrorKind = "conflict"
+	// ErrUsage : the request is malformed or ambiguous, as an invalid
+	// build reference
+	ErrUsage ErrorKind = "usage"
+	// ErrTransport : ernest could not be reached
+	ErrTransport ErrorKind = "transport"
+)
+
+// Error : typed error returned by the manager wrappers
+type Error struct {
+	Kind       ErrorKind
+	Message    string
+	Validation *emodels.Validation
+}
+
+// Error : returns the error message
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// NewError : creates a new typed error
+func NewError(kind ErrorKind, msg string) *Error {
+	return &Error{Kind: kind, Message: msg}
+}
+
+// Kind : gets the kind of an error returned by the manager
+func Kind(err error) ErrorKind {
+	if e, ok := err.(*Error); ok {
+		return e.Kind
+	}
+	return ErrUnknown
+}
+
+// IsNotFound : reports if an error is due to a missing resource
+func IsNotFound(err error) bool {
+	return Kind(err) == ErrNotFound
+}
+
+// statusCoder : errors carrying the http status code of the failed request
+type statusCoder interface {
+	StatusCode() int
+}
+
+var statusKinds = map[int]ErrorKind{
+	http.StatusBadRequest:          ErrValidation,
+	http.StatusUnauthorized:        ErrUnauthorized,
+	http.StatusForbidden:           ErrForbidden,
+	http.StatusNotFound:            ErrNotFound,
+	http.StatusConflict:            ErrConflict,
+	http.StatusUnprocessableEntity: ErrValidation,
+}
+
+// messageKinds : known api messages, used when the sdk does not expose
+// the status code. Messages are matched whole, ignoring case
+var messageKinds = map[string]ErrorKind{
+	"not found":                                 ErrNotFound,
+	"environment not found":                     ErrNotFound,
+	"project not found":                         ErrNotFound,
+	"build not found":                           ErrNotFound,
+	"user not found":                            ErrNotFound,
+	"policy not found":                          ErrNotFound,
+	"notification not found":                    ErrNotFound,
+	"specified environment name does not exist": ErrNotFound,
+	"specified project does not exist":          ErrNotFound,
+	"unauthorized":                              ErrUnauthorized,
+	"forbidden":                                 ErrForbidden,
+	"invalid token":                             ErrUnauthorized,
+	"token is expired":                          ErrUnauthorized,
+	"authentication failed":                     ErrUnauthorized,
+	"invalid credentials":                       ErrUnauthorized,
+	"mfa required":                              ErrUnauthorized,
+	"you don't have permissions to perform this action": ErrForbidden,
+	"environment already exists":                        ErrConflict,
+	"project already exists":                            ErrConflict,
+	"environment is already in progress":                ErrConflict,
+	"build is already in progress":                      ErrConflict,
+	"validation failed":                                 ErrValidation,
+	"bad request":                                       ErrValidation,
+}
+
+// wrap : classifies an error returned by the ernest go sdk, by its status
+// code when available or else by its message
+func wrap(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := err.(*Error); ok {
+		return err
+	}
+
+	e := &Error{Kind: ErrUnknown, Message: err.Error()}
+
+	switch v := err.(type) {
+	case *emodels.Error:
+		if v.Validation != nil {
+			e.Kind = ErrValidation
+			e.Validation = v.Validation
+			return e
+		}
+	case *url.Error, *net.OpError:
+		e.Kind = ErrTransport
+		return e
+	}
+
+	if sc, ok := err.(statusCoder); ok {
+		if kind, ok := statusKinds[sc.StatusCode()]; ok {
+			e.Kind = kind
+		}
+		return e
+	}
+
+	msg := strings.ToLower(strings.TrimSpace(e.Message))
+	if strings.Contains(msg, "connection refused") || strings.Contains(msg, "no such host") || strings.Contains(msg, "i/o timeout") {
+		e.Kind = ErrTransport
+		return e
+	}
+
+	msg = strings.Replace(strings.TrimSuffix(msg, "."), "’", "'", -1)
+	if kind, ok := messageKinds[msg]; ok {
+		e.Kind = kind
+	}
+
+	return e
+}
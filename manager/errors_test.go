@@ -0,0 +1,54 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package manager
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+)
+
+type statusError struct {
+	code int
+	msg  string
+}
+
+func (e *statusError) Error() string   { return e.msg }
+func (e *statusError) StatusCode() int { return e.code }
+
+func TestWrap(t *testing.T) {
+	tests := []struct {
+		err  error
+		want ErrorKind
+	}{
+		{&statusError{404, "whatever the api says"}, ErrNotFound},
+		{&statusError{401, "x"}, ErrUnauthorized},
+		{&statusError{403, "x"}, ErrForbidden},
+		{&statusError{409, "x"}, ErrConflict},
+		{&statusError{400, "x"}, ErrValidation},
+		{&statusError{500, "Environment not found"}, ErrUnknown},
+		{errors.New("Environment not found"), ErrNotFound},
+		{errors.New("Specified environment name does not exist."), ErrNotFound},
+		{errors.New("You don’t have permissions to perform this action"), ErrForbidden},
+		{errors.New("Forbidden"), ErrForbidden},
+		{errors.New("Token is expired"), ErrUnauthorized},
+		{errors.New("Environment is already in progress"), ErrConflict},
+		{errors.New("Policy 'not found' checks failed"), ErrUnknown},
+		{errors.New("Invalid input: the name is not found on the dns"), ErrUnknown},
+		{&url.Error{Op: "Get", URL: "https://ernest", Err: errors.New("eof")}, ErrTransport},
+		{errors.New("dial tcp: lookup ernest: no such host"), ErrTransport},
+		{NewError(ErrConflict, "locked"), ErrConflict},
+	}
+
+	for _, tt := range tests {
+		if got := Kind(wrap(tt.err)); got != tt.want {
+			t.Errorf("wrap(%q) kind = %s, want %s", tt.err.Error(), got, tt.want)
+		}
+	}
+
+	if wrap(nil) != nil {
+		t.Error("wrap(nil) should be nil")
+	}
+}
@@ -5,45 +5,46 @@
 package manager
 
 import (
-	h "github.com/ernestio/ernest-cli/helper"
 	eclient "github.com/ernestio/ernest-go-sdk/client"
 	emodels "github.com/ernestio/ernest-go-sdk/models"
 )
 
 // Logger : ernest-go-sdk Logger wrapper
 type Logger struct {
-	cli *eclient.Client
+	cli  *eclient.Client
+	auth *auth
 }
 
 // Create : Creates a new logger
-func (c *Logger) Create(logger *emodels.Logger) {
-	if err := c.cli.Loggers.Create(logger); err != nil {
-		h.PrintError(err.Error())
-	}
+func (c *Logger) Create(logger *emodels.Logger) error {
+	return c.auth.do(func() error {
+		return c.cli.Loggers.Create(logger)
+	})
 }
 
 // List : lists all available loggers
-func (c *Logger) List() []*emodels.Logger {
-	loggers, err := c.cli.Loggers.List()
-	if err != nil {
-		h.PrintError(err.Error())
-		return nil
-	}
-	return loggers
+func (c *Logger) List() ([]*emodels.Logger, error) {
+	var loggers []*emodels.Logger
+	err := c.auth.do(func() (err error) {
+		loggers, err = c.cli.Loggers.List()
+		return err
+	})
+	return loggers, err
 }
 
 // Delete : Deletes logger by name
-func (c *Logger) Delete(name string) {
-	if err := c.cli.Loggers.Delete(name); err != nil {
-		h.PrintError(err.Error())
-	}
+func (c *Logger) Delete(name string) error {
+	return c.auth.do(func() error {
+		return c.cli.Loggers.Delete(name)
+	})
 }
 
 // Stream : Streams log events
-func (c *Logger) Stream() chan []byte {
-	ch, err := c.cli.Conn.WSStream("/logs", "logs")
-	if err != nil {
-		h.PrintError(err.Error())
-	}
-	return ch
+func (c *Logger) Stream() (chan []byte, error) {
+	var ch chan []byte
+	err := c.auth.do(func() (err error) {
+		ch, err = c.cli.Conn.WSStream("/logs", "logs")
+		return err
+	})
+	return ch, err
 }
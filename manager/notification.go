@@ -7,80 +7,80 @@ package manager
 import (
 	"fmt"
 
-	h "github.com/ernestio/ernest-cli/helper"
 	eclient "github.com/ernestio/ernest-go-sdk/client"
 	emodels "github.com/ernestio/ernest-go-sdk/models"
 )
 
 // Notification : ernest-go-sdk Notification wrapper
 type Notification struct {
-	cli *eclient.Client
+	cli  *eclient.Client
+	auth *auth
 }
 
 // Get : Gets a notification by name
-func (c *Notification) Get(id string) *emodels.Notification {
-	notification, err := c.cli.Notifications.Get(id)
-	if err != nil {
-		h.PrintError(err.Error())
-	}
-	return notification
+func (c *Notification) Get(id string) (*emodels.Notification, error) {
+	var notification *emodels.Notification
+	err := c.auth.do(func() (err error) {
+		notification, err = c.cli.Notifications.Get(id)
+		return err
+	})
+	return notification, err
 }
 
 // Update : Updates a notification
-func (c *Notification) Update(notification *emodels.Notification) {
-	if err := c.cli.Notifications.Update(notification); err != nil {
-		h.PrintError(err.Error())
-	}
+func (c *Notification) Update(notification *emodels.Notification) error {
+	return c.auth.do(func() error {
+		return c.cli.Notifications.Update(notification)
+	})
 }
 
 // Create : Creates a new notification
-func (c *Notification) Create(notification *emodels.Notification) {
-	if err := c.cli.Notifications.Create(notification); err != nil {
-		h.PrintError(err.Error())
-	}
+func (c *Notification) Create(notification *emodels.Notification) error {
+	return c.auth.do(func() error {
+		return c.cli.Notifications.Create(notification)
+	})
 }
 
 // List : Lists all notifications on the system
-func (c *Notification) List() []*emodels.Notification {
-	notifications, err := c.cli.Notifications.List()
-	if err != nil {
-		h.PrintError(err.Error())
-	}
-	return notifications
+func (c *Notification) List() ([]*emodels.Notification, error) {
+	var notifications []*emodels.Notification
+	err := c.auth.do(func() (err error) {
+		notifications, err = c.cli.Notifications.List()
+		return err
+	})
+	return notifications, err
 }
 
 // Delete : Deletes a notification and all its relations
-func (c *Notification) Delete(notification string) {
-	if err := c.cli.Notifications.Delete(notification); err != nil {
-		h.PrintError(err.Error())
-	}
+func (c *Notification) Delete(notification string) error {
+	return c.auth.do(func() error {
+		return c.cli.Notifications.Delete(notification)
+	})
 }
 
 // AddProject : Adds a project to a notification
-func (c *Notification) AddProject(notification, project string) {
-	n, err := c.cli.Notifications.Get(notification)
+func (c *Notification) AddProject(notification, project string) error {
+	n, err := c.Get(notification)
 	if err != nil {
-		h.PrintError(err.Error())
+		return err
 	}
 
 	for _, source := range n.Sources {
 		if source == project {
-			h.PrintError("project is already added to notification")
+			return NewError(ErrConflict, "project is already added to notification")
 		}
 	}
 
 	n.Sources = append(n.Sources, project)
 
-	if err := c.cli.Notifications.Update(n); err != nil {
-		h.PrintError(err.Error())
-	}
+	return c.Update(n)
 }
 
 // RmProject : Removes a project from a notification
-func (c *Notification) RmProject(notification, project string) {
-	n, err := c.cli.Notifications.Get(notification)
+func (c *Notification) RmProject(notification, project string) error {
+	n, err := c.Get(notification)
 	if err != nil {
-		h.PrintError(err.Error())
+		return err
 	}
 
 	for i := len(n.Sources) - 1; i >= 0; i-- {
@@ -89,38 +89,34 @@ func (c *Notification) RmProject(notification, project string) {
 		}
 	}
 
-	if err := c.cli.Notifications.Update(n); err != nil {
-		h.PrintError(err.Error())
-	}
+	return c.Update(n)
 }
 
 // AddEnv : Adds an environment to a notification
-func (c *Notification) AddEnv(notification, project, env string) {
-	n, err := c.cli.Notifications.Get(notification)
+func (c *Notification) AddEnv(notification, project, env string) error {
+	n, err := c.Get(notification)
 	if err != nil {
-		h.PrintError(err.Error())
+		return err
 	}
 
 	name := fmt.Sprintf("%s/%s", project, env)
 
 	for _, source := range n.Sources {
 		if source == name {
-			h.PrintError("environment is already added to notification")
+			return NewError(ErrConflict, "environment is already added to notification")
 		}
 	}
 
 	n.Sources = append(n.Sources, name)
 
-	if err := c.cli.Notifications.Update(n); err != nil {
-		h.PrintError(err.Error())
-	}
+	return c.Update(n)
 }
 
 // RmEnv : Removes an environment from a notification
-func (c *Notification) RmEnv(notification, project, env string) {
-	n, err := c.cli.Notifications.Get(notification)
+func (c *Notification) RmEnv(notification, project, env string) error {
+	n, err := c.Get(notification)
 	if err != nil {
-		h.PrintError(err.Error())
+		return err
 	}
 
 	name := fmt.Sprintf("%s/%s", project, env)
@@ -131,7 +127,5 @@ func (c *Notification) RmEnv(notification, project, env string) {
 		}
 	}
 
-	if err := c.cli.Notifications.Update(n); err != nil {
-		h.PrintError(err.Error())
-	}
+	return c.Update(n)
 }
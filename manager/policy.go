@@ -5,78 +5,81 @@
 package manager
 
 import (
-	h "github.com/ernestio/ernest-cli/helper"
 	eclient "github.com/ernestio/ernest-go-sdk/client"
 	emodels "github.com/ernestio/ernest-go-sdk/models"
 )
 
 // Policy : ernest-go-sdk Policy wrapper
 type Policy struct {
-	cli *eclient.Client
+	cli  *eclient.Client
+	auth *auth
 }
 
 // Get : Gets a policy by name
-func (c *Policy) Get(id string) *emodels.Policy {
-	policy, err := c.cli.Policies.Get(id)
-	if err != nil {
-		h.PrintError(err.Error())
-	}
-	return policy
+func (c *Policy) Get(id string) (*emodels.Policy, error) {
+	var policy *emodels.Policy
+	err := c.auth.do(func() (err error) {
+		policy, err = c.cli.Policies.Get(id)
+		return err
+	})
+	return policy, err
 }
 
 // Update : Updates a policy
-func (c *Policy) Update(policy *emodels.Policy) {
-	if err := c.cli.Policies.Update(policy); err != nil {
-		h.PrintError(err.Error())
-	}
+func (c *Policy) Update(policy *emodels.Policy) error {
+	return c.auth.do(func() error {
+		return c.cli.Policies.Update(policy)
+	})
 }
 
 // Create : Creates a new policy
-func (c *Policy) Create(policy *emodels.Policy) {
-	if err := c.cli.Policies.Create(policy); err != nil {
-		h.PrintError(err.Error())
-	}
+func (c *Policy) Create(policy *emodels.Policy) error {
+	return c.auth.do(func() error {
+		return c.cli.Policies.Create(policy)
+	})
 }
 
 // List : Lists all policies on the system
-func (c *Policy) List() []*emodels.Policy {
-	policies, err := c.cli.Policies.List()
-	if err != nil {
-		h.PrintError(err.Error())
-	}
-	return policies
+func (c *Policy) List() ([]*emodels.Policy, error) {
+	var policies []*emodels.Policy
+	err := c.auth.do(func() (err error) {
+		policies, err = c.cli.Policies.List()
+		return err
+	})
+	return policies, err
 }
 
 // Delete : Deletes a policy and all its relations
-func (c *Policy) Delete(policy string) {
-	if err := c.cli.Policies.Delete(policy); err != nil {
-		h.PrintError(err.Error())
-	}
+func (c *Policy) Delete(policy string) error {
+	return c.auth.do(func() error {
+		return c.cli.Policies.Delete(policy)
+	})
 }
 
 // GetDocument : Gets a policy document by revision
-func (c *Policy) GetDocument(policy, revision string) *emodels.PolicyDocument {
-	document, err := c.cli.Policies.GetDocument(policy, revision)
-	if err != nil {
-		h.PrintError(err.Error())
-	}
-
-	return document
+func (c *Policy) GetDocument(policy, revision string) (*emodels.PolicyDocument, error) {
+	var document *emodels.PolicyDocument
+	err := c.auth.do(func() (err error) {
+		document, err = c.cli.Policies.GetDocument(policy, revision)
+		return err
+	})
+	return document, err
 }
 
 // ListDocuments : Lists all policy documents by policy name
-func (c *Policy) ListDocuments(policy string) []*emodels.PolicyDocument {
-	documents, err := c.cli.Policies.ListDocuments(policy)
-	if err != nil {
-		h.PrintError(err.Error())
-	}
-
-	return documents
+func (c *Policy) ListDocuments(policy string) ([]*emodels.PolicyDocument, error) {
+	var documents []*emodels.PolicyDocument
+	err := c.auth.do(func() (err error) {
+		documents, err = c.cli.Policies.ListDocuments(policy)
+		return err
+	})
+	return documents, err
 }
 
 // CreateDocument : Creates a policy document and all its relations
-func (c *Policy) CreateDocument(policy, document string) {
-	if _, err := c.cli.Policies.CreateDocument(policy, document); err != nil {
-		h.PrintError(err.Error())
-	}
+func (c *Policy) CreateDocument(policy, document string) error {
+	return c.auth.do(func() error {
+		_, err := c.cli.Policies.CreateDocument(policy, document)
+		return err
+	})
 }
@@ -5,51 +5,53 @@
 package manager
 
 import (
-	h "github.com/ernestio/ernest-cli/helper"
 	eclient "github.com/ernestio/ernest-go-sdk/client"
 	emodels "github.com/ernestio/ernest-go-sdk/models"
 )
 
 // Project : ernest-go-sdk Project wrapper
 type Project struct {
-	cli *eclient.Client
+	cli  *eclient.Client
+	auth *auth
 }
 
 // Create : ...
-func (c *Project) Create(project *emodels.Project) {
-	if err := c.cli.Projects.Create(project); err != nil {
-		h.PrintError(err.Error())
-	}
+func (c *Project) Create(project *emodels.Project) error {
+	return c.auth.do(func() error {
+		return c.cli.Projects.Create(project)
+	})
 }
 
 // Delete : Deletes a project and all its relations
-func (c *Project) Delete(project string) {
-	if err := c.cli.Projects.Delete(project); err != nil {
-		h.PrintError(err.Error())
-	}
+func (c *Project) Delete(project string) error {
+	return c.auth.do(func() error {
+		return c.cli.Projects.Delete(project)
+	})
 }
 
 // Get : Gets a project by name
-func (c *Project) Get(id string) *emodels.Project {
-	project, err := c.cli.Projects.Get(id)
-	if err != nil {
-		h.PrintError(err.Error())
-	}
-	return project
+func (c *Project) Get(id string) (*emodels.Project, error) {
+	var project *emodels.Project
+	err := c.auth.do(func() (err error) {
+		project, err = c.cli.Projects.Get(id)
+		return err
+	})
+	return project, err
 }
 
 // Update : Updates a notification
-func (c *Project) Update(project *emodels.Project) {
-	if err := c.cli.Projects.Update(project); err != nil {
-		h.PrintError(err.Error())
-	}
+func (c *Project) Update(project *emodels.Project) error {
+	return c.auth.do(func() error {
+		return c.cli.Projects.Update(project)
+	})
 }
 
 // List : Lists all projects on the system
-func (c *Project) List() []*emodels.Project {
-	projects, err := c.cli.Projects.List()
-	if err != nil {
-		h.PrintError(err.Error())
-	}
-	return projects
+func (c *Project) List() ([]*emodels.Project, error) {
+	var projects []*emodels.Project
+	err := c.auth.do(func() (err error) {
+		projects, err = c.cli.Projects.List()
+		return err
+	})
+	return projects, err
 }
@@ -5,20 +5,21 @@
 package manager
 
 import (
-	h "github.com/ernestio/ernest-cli/helper"
 	eclient "github.com/ernestio/ernest-go-sdk/client"
 )
 
 // Report : ernest-go-sdk Report wrapper
 type Report struct {
-	cli *eclient.Client
+	cli  *eclient.Client
+	auth *auth
 }
 
 // Usage : Gets an usage report
-func (c *Report) Usage(from, to string) []byte {
-	usage, err := c.cli.Reports.Usage(from, to)
-	if err != nil {
-		h.PrintError(err.Error())
-	}
-	return usage
+func (c *Report) Usage(from, to string) ([]byte, error) {
+	var usage []byte
+	err := c.auth.do(func() (err error) {
+		usage, err = c.cli.Reports.Usage(from, to)
+		return err
+	})
+	return usage, err
 }
@@ -5,26 +5,26 @@
 package manager
 
 import (
-	h "github.com/ernestio/ernest-cli/helper"
 	eclient "github.com/ernestio/ernest-go-sdk/client"
 	emodels "github.com/ernestio/ernest-go-sdk/models"
 )
 
 // Role : ernest-go-sdk Roles wrapper
 type Role struct {
-	cli *eclient.Client
+	cli  *eclient.Client
+	auth *auth
 }
 
 // Create : Creates a new role
-func (c *Role) Create(role *emodels.Role) {
-	if err := c.cli.Roles.Create(role); err != nil {
-		h.PrintError(err.Error())
-	}
+func (c *Role) Create(role *emodels.Role) error {
+	return c.auth.do(func() error {
+		return c.cli.Roles.Create(role)
+	})
 }
 
 // Delete : Deletes a role and all its relations
-func (c *Role) Delete(role *emodels.Role) {
-	if err := c.cli.Roles.Delete(role); err != nil {
-		h.PrintError(err.Error())
-	}
+func (c *Role) Delete(role *emodels.Role) error {
+	return c.auth.do(func() error {
+		return c.cli.Roles.Delete(role)
+	})
 }
@@ -5,21 +5,28 @@
 package manager
 
 import (
-	h "github.com/ernestio/ernest-cli/helper"
 	eclient "github.com/ernestio/ernest-go-sdk/client"
 	emodels "github.com/ernestio/ernest-go-sdk/models"
 )
 
 // Session : ernest-go-sdk Session wrapper
 type Session struct {
-	cli *eclient.Client
+	cli  *eclient.Client
+	auth *auth
 }
 
 // Get : ..
-func (c *Session) Get() *emodels.Session {
-	ses, err := c.cli.Sessions.Get()
+func (c *Session) Get() (*emodels.Session, error) {
+	var ses *emodels.Session
+	err := c.auth.do(func() (err error) {
+		ses, err = c.cli.Sessions.Get()
+		return err
+	})
 	if err != nil {
-		h.PrintError("You don’t have permissions to perform this action")
+		if Kind(err) == ErrTransport {
+			return nil, err
+		}
+		return nil, NewError(ErrUnauthorized, "You don’t have permissions to perform this action")
 	}
-	return ses
+	return ses, nil
 }
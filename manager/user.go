@@ -5,62 +5,69 @@
 package manager
 
 import (
-	h "github.com/ernestio/ernest-cli/helper"
 	eclient "github.com/ernestio/ernest-go-sdk/client"
 	emodels "github.com/ernestio/ernest-go-sdk/models"
 )
 
 // User : ernest-go-sdk User wrapper
 type User struct {
-	cli *eclient.Client
+	cli  *eclient.Client
+	auth *auth
 }
 
 // Get : ...
-func (c *User) Get(username string) *emodels.User {
-	user, err := c.cli.Users.Get(username)
-	if err != nil {
-		h.PrintError(err.Error())
-	}
-	return user
+func (c *User) Get(username string) (*emodels.User, error) {
+	var user *emodels.User
+	err := c.auth.do(func() (err error) {
+		user, err = c.cli.Users.Get(username)
+		return err
+	})
+	return user, err
 }
 
 // Update : ...
-func (c *User) Update(user *emodels.User) {
-	if err := c.cli.Users.Update(user); err != nil {
-		h.PrintError(err.Error())
-	}
+func (c *User) Update(user *emodels.User) error {
+	return c.auth.do(func() error {
+		return c.cli.Users.Update(user)
+	})
 }
 
 // Create : ...
-func (c *User) Create(user *emodels.User) {
-	if err := c.cli.Users.Create(user); err != nil {
-		h.PrintError(err.Error())
-	}
+func (c *User) Create(user *emodels.User) error {
+	return c.auth.do(func() error {
+		return c.cli.Users.Create(user)
+	})
 }
 
 // List : ...
-func (c *User) List() []*emodels.User {
-	users, err := c.cli.Users.List()
-	if err != nil {
-		h.PrintError(err.Error())
-	}
-	return users
+func (c *User) List() ([]*emodels.User, error) {
+	var users []*emodels.User
+	err := c.auth.do(func() (err error) {
+		users, err = c.cli.Users.List()
+		return err
+	})
+	return users, err
 }
 
 // Promote : ...
-func (c *User) Promote(user *emodels.User) {
+func (c *User) Promote(user *emodels.User) error {
 	user.Admin = true
-	if err := c.cli.Users.Update(user); err != nil {
+	if err := c.Update(user); err != nil {
+		e := err.(*Error)
 		str1 := "It was not possible to set this user as admin: "
 		str2 := "Please fix any errors and try again with 'user admin add ...' command"
-		h.PrintError(str1 + err.Error() + "\n" + str2)
+		e.Message = str1 + e.Message + "\n" + str2
+		return e
 	}
+	return nil
 }
 
 // ToggleMFA : ...
-func (c *User) ToggleMFA(user *emodels.User, toggle bool) (res string) {
+func (c *User) ToggleMFA(user *emodels.User, toggle bool) (res string, err error) {
 	user.MFA = &toggle
-	c.Update(user)
+	if err = c.Update(user); err != nil {
+		return
+	}
 
 	if toggle {
 		res = user.MFASecret
@@ -0,0 +1,117 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package model
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// BuildTag : a name given to a build of an environment, tags are kept on
+// the profile config as builds belong to its ernest instance
+type BuildTag struct {
+	Name      string `json:"name"`
+	Build     string `json:"build"`
+	Message   string `json:"message,omitempty"`
+	User      string `json:"user,omitempty"`
+	CreatedAt string `json:"created_at"`
+}
+
+var validTagName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._\-/]*$`)
+var numericTagName = regexp.MustCompile(`^[0-9]+$`)
+
+// ValidateTagName : checks a tag name can't be confused with other build
+// references
+func ValidateTagName(name string) error {
+	switch {
+	case !validTagName.MatchString(name):
+		return errors.New("Invalid tag '" + name + "', it can only contain letters, numbers, '.', '_', '-' and '/'")
+	case numericTagName.MatchString(name):
+		return errors.New("Invalid tag '" + name + "', it can't be a number as it would be taken as a build position")
+	case name == "latest" || strings.HasPrefix(name, "latest~") || name == "last-successful":
+		return errors.New("Invalid tag '" + name + "', it is a reserved build reference")
+	}
+	return nil
+}
+
+func tagKey(project, env string) string {
+	return project + "/" + env
+}
+
+// Tags : gets the tags of the builds of an environment
+func (c *Config) Tags(project, env string) []BuildTag {
+	return c.BuildTags[tagKey(project, env)]
+}
+
+// FindTag : gets a tag of an environment by its name
+func (c *Config) FindTag(project, env, name string) (BuildTag, bool) {
+	for _, t := range c.Tags(project, env) {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return BuildTag{}, false
+}
+
+// AddTag : adds a tag to an environment, replacing any tag with the same
+// name
+func (c *Config) AddTag(project, env string, tag BuildTag) {
+	if c.BuildTags == nil {
+		c.BuildTags = make(map[string][]BuildTag)
+	}
+
+	key := tagKey(project, env)
+	tags := make([]BuildTag, 0)
+	for _, t := range c.BuildTags[key] {
+		if t.Name != tag.Name {
+			tags = append(tags, t)
+		}
+	}
+	c.BuildTags[key] = append(tags, tag)
+}
+
+// RemoveTag : removes a tag from an environment, reporting if it existed
+func (c *Config) RemoveTag(project, env, name string) bool {
+	key := tagKey(project, env)
+	tags := make([]BuildTag, 0)
+	for _, t := range c.BuildTags[key] {
+		if t.Name != name {
+			tags = append(tags, t)
+		}
+	}
+	if len(tags) == len(c.BuildTags[key]) {
+		return false
+	}
+
+	if len(tags) == 0 {
+		delete(c.BuildTags, key)
+	} else {
+		c.BuildTags[key] = tags
+	}
+	return true
+}
+
+// BuildTagNames : gets the names of the tags of a build
+func (c *Config) BuildTagNames(project, env, id string) []string {
+	names := make([]string, 0)
+	for _, t := range c.Tags(project, env) {
+		if t.Build == id {
+			names = append(names, t.Name)
+		}
+	}
+	return names
+}
+
+// BuildTagMessages : gets the messages given to the tags of a build
+func (c *Config) BuildTagMessages(project, env, id string) []string {
+	messages := make([]string, 0)
+	for _, t := range c.Tags(project, env) {
+		if t.Build == id && t.Message != "" {
+			messages = append(messages, t.Message)
+		}
+	}
+	return messages
+}
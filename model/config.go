@@ -7,40 +7,164 @@ package model
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"log"
 	"os"
+	"sort"
 	"strings"
 
 	"github.com/mitchellh/go-homedir"
 )
 
+// DefaultProfile is the profile name used when none has been configured,
+// and the one given to a config migrated from the single target format
+const DefaultProfile = "default"
+
+// ActiveProfile overrides the profile currently in use, it is set with the
+// --profile flag
+var ActiveProfile string
+
 // Config is the configuration struct for this app
 type Config struct {
+	Name         string `json:"-"`
 	URL          string `json:"url"`
 	Token        string `json:"token"`
 	User         string `json:"user"`
 	Password     string `json:"-"`
 	UserID       string `json:"userid"`
 	Verification string `json:"verification_code"`
+	// SecretStore keeps the token out of the .ernest file, when it is not
+	// the plaintext store
+	SecretStore string `json:"secret_store,omitempty"`
+	storedToken string
+	// BuildTags are the tags given to builds, by project/environment
+	BuildTags map[string][]BuildTag `json:"build_tags,omitempty"`
+	// CredentialSets are the named provider credentials of the profile
+	CredentialSets map[string]*CredentialSet `json:"credential_sets,omitempty"`
+}
+
+// Profiles is the content of the .ernest file, a set of named configs
+// and the one currently in use
+type Profiles struct {
+	Current  string             `json:"current"`
+	Profiles map[string]*Config `json:"profiles"`
 }
 
-// GetConfig : Get config defined on the .ernest file
-func GetConfig() *Config {
-	source := getConfigPath()
-	c := Config{}
-	file, err := os.Open(source)
+// GetConfig : Get config defined on the .ernest file for the active profile,
+// nil if it is not configured. Profiles selected with --profile or
+// ERNEST_PROFILE must exist
+func GetConfig() (*Config, error) {
+	p := GetProfiles()
+	if p == nil {
+		return nil, nil
+	}
+
+	name := p.Active()
+	c, ok := p.Profiles[name]
+	if !ok {
+		if ActiveProfile != "" || os.Getenv("ERNEST_PROFILE") != "" {
+			return nil, fmt.Errorf("profile %q does not exist", name)
+		}
+		return nil, nil
+	}
+	c.Name = name
+	c.URL = strings.TrimSuffix(c.URL, "/")
+	if err := c.loadSecrets(); err != nil {
+		log.Println("Can't read the token from the " + c.SecretStore + " secret store: " + err.Error())
+	}
+	return c, nil
+}
+
+// GetProfiles : Get all profiles defined on the .ernest file. Files written
+// by previous versions, holding a single config, are loaded as the default
+// profile
+func GetProfiles() *Profiles {
+	payload, err := ioutil.ReadFile(getConfigPath())
 	if err != nil {
 		return nil
 	}
-	decoder := json.NewDecoder(file)
-	err = decoder.Decode(&c)
+
+	fields := make(map[string]json.RawMessage)
+	if err = json.Unmarshal(payload, &fields); err != nil {
+		log.Println("Config file is invalid")
+		log.Panic("error:", err)
+	}
+
+	p := Profiles{Profiles: make(map[string]*Config)}
+	if _, ok := fields["profiles"]; ok {
+		err = json.Unmarshal(payload, &p)
+	} else {
+		c := Config{}
+		err = json.Unmarshal(payload, &c)
+		p.Current = DefaultProfile
+		p.Profiles[DefaultProfile] = &c
+	}
 	if err != nil {
 		log.Println("Config file is invalid")
 		log.Panic("error:", err)
 	}
-	c.URL = strings.TrimSuffix(c.URL, "/")
-	return &c
+
+	for name, c := range p.Profiles {
+		c.Name = name
+	}
+
+	return &p
+}
+
+// Active : Get the name of the profile in use, the --profile flag takes
+// precedence over the ERNEST_PROFILE environment variable and the current
+// profile
+func (p *Profiles) Active() string {
+	if ActiveProfile != "" {
+		return ActiveProfile
+	}
+	if env := os.Getenv("ERNEST_PROFILE"); env != "" {
+		return env
+	}
+	if p != nil && p.Current != "" {
+		return p.Current
+	}
+	return DefaultProfile
+}
+
+// Names : Get the sorted list of profile names
+func (p *Profiles) Names() []string {
+	names := make([]string, 0, len(p.Profiles))
+	for name := range p.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Save : writes all profiles to the .ernest file, the tokens of profiles
+// using a secret store are written to their store instead
+func (p *Profiles) Save() error {
+	file := Profiles{Current: p.Current, Profiles: make(map[string]*Config)}
+	for name, c := range p.Profiles {
+		if c.Secrets() == SecretStorePlain {
+			file.Profiles[name] = c
+			continue
+		}
+		if err := c.saveSecrets(); err != nil {
+			return err
+		}
+		cp := *c
+		cp.Token = ""
+		file.Profiles[name] = &cp
+	}
+
+	body, err := json.Marshal(file)
+	if err != nil {
+		return errors.New("Can't save config file")
+	}
+	err = ioutil.WriteFile(getConfigPath(), body, 0600)
+	if err != nil {
+		return errors.New("Can't save config file")
+	}
+
+	return nil
 }
 
 // Get the config path to use, default is .ernest on the same
@@ -54,16 +178,21 @@ func getConfigPath() string {
 	return dir + "/.ernest"
 }
 
-// SaveConfig ...
+// SaveConfig : stores the config on its profile, keeping all other
+// profiles untouched
 func SaveConfig(c *Config) error {
-	body, err := json.Marshal(&c)
-	if err != nil {
-		return errors.New("Can't save config file")
+	p := GetProfiles()
+	if p == nil {
+		p = &Profiles{Profiles: make(map[string]*Config)}
 	}
-	err = ioutil.WriteFile(getConfigPath(), body, 0600)
-	if err != nil {
-		return errors.New("Can't save config file")
+
+	if c.Name == "" {
+		c.Name = p.Active()
+	}
+	if p.Current == "" {
+		p.Current = c.Name
 	}
+	p.Profiles[c.Name] = c
 
-	return nil
+	return p.Save()
 }
@@ -0,0 +1,156 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// CredentialSet : named provider credentials kept on a profile, given to
+// environments instead of the provider flags
+type CredentialSet struct {
+	Name     string `json:"-"`
+	Provider string `json:"provider"`
+	// Values are kept on the profile secret store, unless it is the
+	// plaintext one
+	Values map[string]interface{} `json:"values,omitempty"`
+}
+
+// providerCredentials : environment credentials accepted by each provider
+var providerCredentials = map[string][]string{
+	"aws":    {"aws_access_key_id", "aws_secret_access_key", "region"},
+	"azure":  {"azure_subscription_id", "azure_client_id", "azure_client_secret", "azure_tenant_id"},
+	"vcloud": {"username", "password", "vcloud_url", "vse_url", "external_network", "region"},
+}
+
+var credentialSetName = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// ProviderType : gets the provider of a project type, fake projects
+// using the credentials of the provider they simulate
+func ProviderType(projectType string) string {
+	return strings.TrimSuffix(projectType, "-fake")
+}
+
+// Validate : checks the credential set only holds credentials of its
+// provider, and the provider of a project matches it when given
+func (s *CredentialSet) Validate(projectType string) error {
+	if !credentialSetName.MatchString(s.Name) {
+		return errors.New("Invalid credentials name '" + s.Name + "', use letters, numbers, '.', '_' and '-'")
+	}
+	allowed, ok := providerCredentials[s.Provider]
+	if !ok {
+		return errors.New("Unsupported provider '" + s.Provider + "', valid providers are aws, azure and vcloud")
+	}
+	if projectType != "" && ProviderType(projectType) != s.Provider {
+		return errors.New("Credentials '" + s.Name + "' are for " + s.Provider + " projects, not " + projectType + " ones")
+	}
+
+	for key := range s.Values {
+		valid := false
+		for _, a := range allowed {
+			valid = valid || a == key
+		}
+		if !valid {
+			return errors.New("Credential " + key + " is not valid for " + s.Provider + " projects")
+		}
+	}
+
+	return nil
+}
+
+// Keys : sorted names of the credentials on the set
+func (s *CredentialSet) Keys() []string {
+	keys := make([]string, 0, len(s.Values))
+	for k := range s.Values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// secretCredentials : key a credential set is stored as
+func secretCredentials(name string) string {
+	return "credentials/" + name
+}
+
+// CredentialSet : gets a credential set of the profile, reading its
+// values from the secret store
+func (c *Config) CredentialSet(name string) (*CredentialSet, error) {
+	set, ok := c.CredentialSets[name]
+	if !ok {
+		return nil, ErrSecretNotFound
+	}
+	set.Name = name
+
+	store, err := GetSecretStore(c.SecretStore)
+	if err != nil || store == nil || set.Values != nil {
+		return set, err
+	}
+
+	body, err := store.Get(c.Name, secretCredentials(name))
+	if err != nil {
+		return nil, errors.New("Can't read credentials '" + name + "' from the " + c.SecretStore + " secret store: " + err.Error())
+	}
+	loaded := *set
+	if err = json.Unmarshal([]byte(body), &loaded.Values); err != nil {
+		return nil, errors.New("Credentials '" + name + "' are not valid")
+	}
+
+	return &loaded, nil
+}
+
+// SetCredentialSet : adds or replaces a credential set of the profile,
+// writing its values to the secret store. The profile has to be saved
+func (c *Config) SetCredentialSet(set *CredentialSet) error {
+	if c.CredentialSets == nil {
+		c.CredentialSets = make(map[string]*CredentialSet)
+	}
+
+	store, err := GetSecretStore(c.SecretStore)
+	if err != nil {
+		return err
+	}
+	if store == nil {
+		c.CredentialSets[set.Name] = set
+		return nil
+	}
+
+	body, err := json.Marshal(set.Values)
+	if err != nil {
+		return err
+	}
+	if err = store.Set(c.Name, secretCredentials(set.Name), string(body)); err != nil {
+		return errors.New("Can't save credentials '" + set.Name + "' on the " + c.SecretStore + " secret store: " + err.Error())
+	}
+	c.CredentialSets[set.Name] = &CredentialSet{Name: set.Name, Provider: set.Provider}
+
+	return nil
+}
+
+// RemoveCredentialSet : removes a credential set of the profile and its
+// values from the secret store. The profile has to be saved
+func (c *Config) RemoveCredentialSet(name string) error {
+	if _, ok := c.CredentialSets[name]; !ok {
+		return ErrSecretNotFound
+	}
+
+	store, err := GetSecretStore(c.SecretStore)
+	if err != nil {
+		return err
+	}
+	if store != nil {
+		err = store.Delete(c.Name, secretCredentials(name))
+		if err != nil && err != ErrSecretNotFound {
+			return err
+		}
+	}
+	delete(c.CredentialSets, name)
+
+	return nil
+}
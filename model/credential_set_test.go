@@ -0,0 +1,65 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package model
+
+import "testing"
+
+func TestCredentialSetValidate(t *testing.T) {
+	aws := map[string]interface{}{"aws_access_key_id": "AKIA", "aws_secret_access_key": "secret", "region": "eu-west-1"}
+
+	tests := []struct {
+		name        string
+		set         CredentialSet
+		projectType string
+		err         string
+	}{
+		{"valid sets", CredentialSet{Name: "prod", Provider: "aws", Values: aws}, "", ""},
+		{"names with dots, dashes and underscores", CredentialSet{Name: "prod_eu-1.a", Provider: "aws"}, "", ""},
+		{"matching project types", CredentialSet{Name: "prod", Provider: "aws", Values: aws}, "aws", ""},
+		{"fake project types", CredentialSet{Name: "prod", Provider: "aws", Values: aws}, "aws-fake", ""},
+		{"vcloud sets", CredentialSet{Name: "dc", Provider: "vcloud", Values: map[string]interface{}{"username": "u", "vcloud_url": "https://vcloud"}}, "vcloud", ""},
+		{"empty names", CredentialSet{Name: "", Provider: "aws"}, "", "Invalid credentials name '', use letters, numbers, '.', '_' and '-'"},
+		{"names with slashes", CredentialSet{Name: "prod/eu", Provider: "aws"}, "", "Invalid credentials name 'prod/eu', use letters, numbers, '.', '_' and '-'"},
+		{"names starting with a dot", CredentialSet{Name: ".prod", Provider: "aws"}, "", "Invalid credentials name '.prod', use letters, numbers, '.', '_' and '-'"},
+		{"names with spaces", CredentialSet{Name: "my prod", Provider: "aws"}, "", "Invalid credentials name 'my prod', use letters, numbers, '.', '_' and '-'"},
+		{"unsupported providers", CredentialSet{Name: "prod", Provider: "gcp"}, "", "Unsupported provider 'gcp', valid providers are aws, azure and vcloud"},
+		{"empty providers", CredentialSet{Name: "prod"}, "", "Unsupported provider '', valid providers are aws, azure and vcloud"},
+		{"fake providers", CredentialSet{Name: "prod", Provider: "aws-fake"}, "", "Unsupported provider 'aws-fake', valid providers are aws, azure and vcloud"},
+		{"other project types", CredentialSet{Name: "prod", Provider: "aws", Values: aws}, "azure", "Credentials 'prod' are for aws projects, not azure ones"},
+		{"other fake project types", CredentialSet{Name: "prod", Provider: "aws", Values: aws}, "vcloud-fake", "Credentials 'prod' are for aws projects, not vcloud-fake ones"},
+		{"keys of other providers", CredentialSet{Name: "prod", Provider: "azure", Values: map[string]interface{}{"aws_access_key_id": "AKIA"}}, "", "Credential aws_access_key_id is not valid for azure projects"},
+		{"unknown keys", CredentialSet{Name: "prod", Provider: "aws", Values: map[string]interface{}{"token": "t"}}, "", "Credential token is not valid for aws projects"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.set.Validate(tt.projectType)
+			if tt.err == "" {
+				if err != nil {
+					t.Errorf("unexpected error %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.err {
+				t.Errorf("expected error %q, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestProviderType(t *testing.T) {
+	tests := map[string]string{
+		"aws":         "aws",
+		"aws-fake":    "aws",
+		"azure-fake":  "azure",
+		"vcloud":      "vcloud",
+		"vcloud-fake": "vcloud",
+	}
+	for in, want := range tests {
+		if got := ProviderType(in); got != want {
+			t.Errorf("ProviderType(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
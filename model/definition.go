@@ -6,10 +6,15 @@ package model
 
 import (
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strings"
 
+	homedir "github.com/mitchellh/go-homedir"
 	yaml "gopkg.in/yaml.v2"
+	yamlv3 "gopkg.in/yaml.v3"
 )
 
 // Definition ...
@@ -18,6 +23,13 @@ type Definition struct {
 	data    yaml.MapSlice
 	Name    string
 	Project string
+	// Path is the file the definition was loaded from, referenced files
+	// are resolved relative to it
+	Path string
+	// Confine rejects referenced files outside of the definition directory
+	Confine bool
+	// Imports lists the referenced files loaded by LoadFileImports
+	Imports []string
 }
 
 // Load the yaml
@@ -40,28 +52,176 @@ func (d *Definition) Save() ([]byte, error) {
 	return yaml.Marshal(d.data)
 }
 
+// LoadIncludes : merges the files listed on the include key of the
+// definition
+func (d *Definition) LoadIncludes() error {
+	found := false
+	for _, item := range d.data {
+		if item.Key == IncludeKey {
+			found = true
+		}
+	}
+	if !found {
+		return nil
+	}
+
+	payload, err := yaml.Marshal(d.data)
+	if err != nil {
+		return err
+	}
+
+	var doc yamlv3.Node
+	if err = yamlv3.Unmarshal(payload, &doc); err != nil {
+		return err
+	}
+
+	root, _, err := resolveIncludes(d.Path, doc.Content[0], d.Confine)
+	if err != nil {
+		if e, ok := err.(*IncludeError); ok {
+			return errors.New(e.Message)
+		}
+		return err
+	}
+
+	if payload, err = yamlv3.Marshal(root); err != nil {
+		return err
+	}
+
+	d.data = nil
+	return d.Load(payload)
+}
+
+// Rename : sets the project and name of the environment the definition
+// is applied to
+func (d *Definition) Rename(project, name string) {
+	d.set("project", project)
+	d.set("name", name)
+	d.Project = project
+	d.Name = name
+}
+
+func (d *Definition) set(key, value string) {
+	for i, item := range d.data {
+		if item.Key == key {
+			d.data[i].Value = value
+			return
+		}
+	}
+	d.data = append(yaml.MapSlice{{Key: key, Value: value}}, d.data...)
+}
+
+// Override : deep merges an overrides file over the definition, with the
+// same rules used for included files. Variables are interpolated on the
+// overrides only, as the definition may already be interpolated. Files
+// referenced by the overrides are resolved relative to it
+func (d *Definition) Override(file string, vars Variables) error {
+	payload, err := ioutil.ReadFile(file)
+	if err != nil {
+		return errors.New("Can't access overrides file " + file)
+	}
+
+	var override yamlv3.Node
+	if err = yamlv3.Unmarshal(payload, &override); err != nil {
+		return errors.New("Overrides file " + file + " is not a valid yaml file")
+	}
+	if len(override.Content) == 0 {
+		return nil
+	}
+	if override.Content[0].Kind != yamlv3.MappingNode {
+		return errors.New("Overrides file " + file + " should be a map")
+	}
+
+	var overrides Definition
+	if err = overrides.Load(payload); err != nil {
+		return errors.New("Overrides file " + file + " is not a valid yaml file")
+	}
+	if err = overrides.Interpolate(vars); err != nil {
+		return err
+	}
+	if payload, err = overrides.Save(); err != nil {
+		return err
+	}
+	override = yamlv3.Node{}
+	if err = yamlv3.Unmarshal(payload, &override); err != nil {
+		return err
+	}
+
+	if payload, err = yaml.Marshal(d.data); err != nil {
+		return err
+	}
+	var base yamlv3.Node
+	if err = yamlv3.Unmarshal(payload, &base); err != nil {
+		return err
+	}
+
+	rebaseImports(override.Content[0], ImportPath("", file))
+	merged := override.Content[0]
+	if len(base.Content) > 0 {
+		inc := includer{origins: make(map[*yamlv3.Node]string)}
+		merged = inc.merge(base.Content[0], merged)
+	}
+
+	if payload, err = yamlv3.Marshal(merged); err != nil {
+		return err
+	}
+
+	d.data = nil
+	return d.Load(payload)
+}
+
+// Interpolate : replaces the variable references on all definition values,
+// failing with the list of undefined variables
+func (d *Definition) Interpolate(vars Variables) error {
+	data, undefined, err := vars.interpolateMapSlice(d.data)
+	if err != nil {
+		return err
+	}
+	if len(undefined) > 0 {
+		return UndefinedVariablesError(undefined)
+	}
+
+	d.data = data
+	for _, item := range d.data {
+		if item.Key != "name" && item.Key != "project" {
+			continue
+		}
+		value, ok := item.Value.(string)
+		if !ok {
+			return fmt.Errorf("Definition %s should be a string", item.Key)
+		}
+		if item.Key == "name" {
+			d.Name = value
+		} else {
+			d.Project = value
+		}
+	}
+
+	return nil
+}
+
 // LoadFileImports : loads any referenced files and maps them to the import definition
 func (d *Definition) LoadFileImports() error {
 	var err error
-	d.data, err = LoadMapSlice(d.data)
+	d.Imports = nil
+	d.data, err = d.LoadMapSlice(d.data)
 	return err
 }
 
 // LoadMapSlice : loads all values into a slice
-func LoadMapSlice(s yaml.MapSlice) (yaml.MapSlice, error) {
+func (d *Definition) LoadMapSlice(s yaml.MapSlice) (yaml.MapSlice, error) {
 	var err error
 	for i, item := range s {
 		switch v := item.Value.(type) {
 		case string:
-			if s[i].Value, err = LoadFile(v); err != nil {
+			if s[i].Value, err = d.LoadFile(v); err != nil {
 				return s, err
 			}
 		case yaml.MapSlice:
-			if s[i].Value, err = LoadMapSlice(v); err != nil {
+			if s[i].Value, err = d.LoadMapSlice(v); err != nil {
 				return s, err
 			}
 		case []interface{}:
-			if s[i].Value, err = LoadSlice(v); err != nil {
+			if s[i].Value, err = d.LoadSlice(v); err != nil {
 				return s, err
 			}
 		}
@@ -70,20 +230,20 @@ func LoadMapSlice(s yaml.MapSlice) (yaml.MapSlice, error) {
 }
 
 // LoadSlice : loads all values into a slice
-func LoadSlice(s []interface{}) ([]interface{}, error) {
+func (d *Definition) LoadSlice(s []interface{}) ([]interface{}, error) {
 	var err error
 	for i, selector := range s {
 		switch v := selector.(type) {
 		case string:
-			if s[i], err = LoadFile(v); err != nil {
+			if s[i], err = d.LoadFile(v); err != nil {
 				return s, err
 			}
 		case []interface{}:
-			if s[i], err = LoadSlice(v); err != nil {
+			if s[i], err = d.LoadSlice(v); err != nil {
 				return s, err
 			}
 		case yaml.MapSlice:
-			if s[i], err = LoadMapSlice(v); err != nil {
+			if s[i], err = d.LoadMapSlice(v); err != nil {
 				return s, err
 			}
 		}
@@ -92,22 +252,73 @@ func LoadSlice(s []interface{}) ([]interface{}, error) {
 }
 
 // LoadFile : determines if the encountered string is
-func LoadFile(path string) (string, error) {
-	if len(path) < 3 || path[:2] != "@{" {
+func (d *Definition) LoadFile(path string) (string, error) {
+	ref, ok := importRef(path)
+	if !ok {
 		return path, nil
 	}
 
-	trimmedPath := path[2:]
-	trimmedPath = trimmedPath[:len(trimmedPath)-1]
-
-	if _, err := os.Stat(trimmedPath); os.IsNotExist(err) {
-		return "", errors.New("Can't access referenced file " + trimmedPath)
+	trimmedPath, err := CheckImport(d.Path, ref, d.Confine)
+	if err != nil {
+		return "", err
 	}
 
 	payload, err := ioutil.ReadFile(trimmedPath)
 	if err != nil {
-		return "", errors.New("Can't access referenced file " + trimmedPath)
+		return "", errors.New("Can't access referenced file " + ref)
 	}
+	d.Imports = append(d.Imports, trimmedPath)
 
 	return string(payload), nil
 }
+
+// importRef : gets the path referenced by an @{path} value
+func importRef(value string) (string, bool) {
+	if len(value) < 3 || value[:2] != "@{" || value[len(value)-1] != '}' {
+		return "", false
+	}
+	return value[2 : len(value)-1], true
+}
+
+// ImportPath : resolves a referenced path relative to the directory of the
+// file referencing it, expanding ~ to the user home
+func ImportPath(file, ref string) string {
+	if expanded, err := homedir.Expand(ref); err == nil {
+		ref = expanded
+	}
+	if !filepath.IsAbs(ref) && file != "" {
+		ref = filepath.Join(filepath.Dir(file), ref)
+	}
+	if abs, err := filepath.Abs(ref); err == nil {
+		return abs
+	}
+	return filepath.Clean(ref)
+}
+
+// CheckImport : resolves a referenced path and checks it can be accessed,
+// when confined it must be inside the directory of the referencing file
+func CheckImport(file, ref string, confine bool) (string, error) {
+	path := ImportPath(file, ref)
+
+	if _, err := os.Stat(path); err != nil {
+		return "", errors.New("Can't access referenced file " + ref)
+	}
+
+	if confine && !insideDir(filepath.Dir(ImportPath("", file)), path) {
+		return "", errors.New("Referenced file " + ref + " is outside of the definition directory")
+	}
+
+	return path, nil
+}
+
+// insideDir : checks if a path is inside a directory, following symlinks
+func insideDir(dir, path string) bool {
+	if d, err := filepath.EvalSymlinks(dir); err == nil {
+		dir = d
+	}
+	if p, err := filepath.EvalSymlinks(path); err == nil {
+		path = p
+	}
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,43 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package model
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/r3labs/diff"
+)
+
+// DriftReport : drift detected on an environment by its last sync
+type DriftReport struct {
+	Project     string   `json:"project"`
+	Environment string   `json:"environment"`
+	Status      string   `json:"status"`
+	Build       string   `json:"build,omitempty"`
+	Drifted     bool     `json:"drifted"`
+	Components  []string `json:"components"`
+	Error       string   `json:"error,omitempty"`
+}
+
+// DriftedComponents : lists the components changed on a build changelog,
+// named as <section>.<name>
+func DriftedComponents(changelog diff.Changelog) []string {
+	seen := make(map[string]bool)
+	components := make([]string, 0)
+	for _, change := range changelog {
+		if len(change.Path) == 0 {
+			continue
+		}
+		id := strings.Replace(change.Path[0], "::", ".", 1)
+		if !seen[id] {
+			seen[id] = true
+			components = append(components, id)
+		}
+	}
+	sort.Strings(components)
+
+	return components
+}
@@ -0,0 +1,60 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+// HistoryEntry : a build listed on the history of an environment
+type HistoryEntry struct {
+	Position  int    `json:"position"`
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	Status    string `json:"status"`
+	User      string `json:"user_name"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+	// Duration is only set for builds which are not running
+	Duration string `json:"duration,omitempty"`
+	// Changes is the number of changed components, nil if the build has
+	// no changelog
+	Changes *int `json:"changes,omitempty"`
+	// Tags given to the build with env build tag
+	Tags []string `json:"tags,omitempty"`
+	// Message of the build tags, joined when it has many
+	Message string `json:"message,omitempty"`
+}
+
+var buildTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999 -0700 MST",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// ParseBuildTime : parses the timestamps of a build
+func ParseBuildTime(s string) (time.Time, error) {
+	for _, layout := range buildTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errors.New("Invalid date '" + s + "'")
+}
+
+// ParseTimeFilter : parses a point in time given as a date, a RFC3339
+// timestamp or a duration before now, as 36h
+func ParseTimeFilter(s string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(-d), nil
+	}
+	t, err := ParseBuildTime(s)
+	if err != nil {
+		return t, errors.New("Invalid date '" + s + "', use a date as 2006-01-02, a timestamp as 2006-01-02T15:04:05Z or a duration as 36h")
+	}
+	return t, nil
+}
@@ -0,0 +1,263 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package model
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+// IncludeKey : top level definition key listing the files it is composed
+// of. Included files are deep merged in order and the including file
+// overrides them, lists of named components are merged by name
+const IncludeKey = "include"
+
+// IncludeError : an error resolving the includes of a definition, with the
+// position of the include causing it
+type IncludeError struct {
+	File    string
+	Line    int
+	Column  int
+	Message string
+}
+
+// Error : formats the error as file:line:column: message
+func (e *IncludeError) Error() string {
+	return fmt.Sprintf("%s:%d:%d: %s", e.File, e.Line, e.Column, e.Message)
+}
+
+type includer struct {
+	// origins maps every loaded node to the file defining it
+	origins map[*yaml.Node]string
+	// root is the definition file, when confined included files must be
+	// inside its directory
+	root    string
+	confine bool
+}
+
+// resolveIncludes : merges all files included by a definition root node,
+// paths are relative to the including file
+func resolveIncludes(file string, root *yaml.Node, confine bool) (*yaml.Node, map[*yaml.Node]string, error) {
+	inc := includer{origins: make(map[*yaml.Node]string), root: file, confine: confine}
+	inc.track(root, file)
+
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		abs = file
+	}
+
+	root, err = inc.resolve(root, file, []string{abs})
+	return root, inc.origins, err
+}
+
+func (inc *includer) track(n *yaml.Node, file string) {
+	inc.origins[n] = file
+	for _, child := range n.Content {
+		inc.track(child, file)
+	}
+}
+
+func (inc *includer) resolve(root *yaml.Node, file string, stack []string) (*yaml.Node, error) {
+	if root.Kind != yaml.MappingNode {
+		return root, nil
+	}
+
+	var includes []*yaml.Node
+	local := *root
+	local.Content = nil
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+		if key.Value != IncludeKey {
+			local.Content = append(local.Content, key, value)
+			continue
+		}
+
+		switch value.Kind {
+		case yaml.ScalarNode:
+			includes = append(includes, value)
+		case yaml.SequenceNode:
+			includes = append(includes, value.Content...)
+		default:
+			return nil, inc.errorf(value, "%s should be a file or a list of files", IncludeKey)
+		}
+	}
+	if len(includes) == 0 {
+		return root, nil
+	}
+	inc.origins[&local] = inc.origins[root]
+
+	var base *yaml.Node
+	for _, n := range includes {
+		if n.Kind != yaml.ScalarNode || n.Value == "" {
+			return nil, inc.errorf(n, "%s should be a file or a list of files", IncludeKey)
+		}
+
+		path := ImportPath(file, n.Value)
+		if inc.confine && !insideDir(filepath.Dir(ImportPath("", inc.root)), path) {
+			return nil, inc.errorf(n, "included file %s is outside of the definition directory", n.Value)
+		}
+		for i, p := range stack {
+			if p == path {
+				cycle := append(append([]string{}, stack[i:]...), path)
+				for j := range cycle {
+					cycle[j] = displayPath(cycle[j])
+				}
+				return nil, inc.errorf(n, "include cycle detected: %s", strings.Join(cycle, " -> "))
+			}
+		}
+
+		payload, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, inc.errorf(n, "can't access included file %s", n.Value)
+		}
+
+		var doc yaml.Node
+		if err := yaml.Unmarshal(payload, &doc); err != nil {
+			return nil, inc.errorf(n, "included file %s is not valid yaml: %s", n.Value, err.Error())
+		}
+		if len(doc.Content) == 0 {
+			continue
+		}
+		if doc.Content[0].Kind != yaml.MappingNode {
+			return nil, inc.errorf(n, "included file %s should be a map", n.Value)
+		}
+
+		child := doc.Content[0]
+		inc.track(child, displayPath(path))
+		rebaseImports(child, path)
+		child, err = inc.resolve(child, path, append(append([]string{}, stack...), path))
+		if err != nil {
+			return nil, err
+		}
+
+		base = inc.merge(base, child)
+	}
+
+	return inc.merge(base, &local), nil
+}
+
+func (inc *includer) errorf(n *yaml.Node, format string, args ...interface{}) error {
+	return &IncludeError{
+		File:    inc.origins[n],
+		Line:    n.Line,
+		Column:  n.Column,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+// merge : deep merges two nodes, the override node wins on conflicts
+func (inc *includer) merge(base, override *yaml.Node) *yaml.Node {
+	if base == nil {
+		return override
+	}
+	if base.Kind == yaml.AliasNode {
+		base = base.Alias
+	}
+	if override.Kind == yaml.AliasNode {
+		override = override.Alias
+	}
+	// an explicitly empty list clears the included one
+	if override.Kind == yaml.SequenceNode && len(override.Content) == 0 {
+		return override
+	}
+
+	switch {
+	case base.Kind == yaml.MappingNode && override.Kind == yaml.MappingNode:
+		merged := inc.copy(override)
+		merged.Content = nil
+		for i := 0; i+1 < len(override.Content); i += 2 {
+			key, value := override.Content[i], override.Content[i+1]
+			if j := mappingIndex(base, key.Value); j >= 0 {
+				value = inc.merge(base.Content[j+1], value)
+			}
+			merged.Content = append(merged.Content, key, value)
+		}
+		for i := 0; i+1 < len(base.Content); i += 2 {
+			if mappingIndex(override, base.Content[i].Value) < 0 {
+				merged.Content = append(merged.Content, base.Content[i], base.Content[i+1])
+			}
+		}
+		return merged
+	case base.Kind == yaml.SequenceNode && override.Kind == yaml.SequenceNode && isNamedList(base) && isNamedList(override):
+		merged := inc.copy(override)
+		merged.Content = append([]*yaml.Node{}, base.Content...)
+		for _, item := range override.Content {
+			name := mappingValue(item, "name").Value
+			if j := namedIndex(merged, name); j >= 0 {
+				merged.Content[j] = inc.merge(merged.Content[j], item)
+			} else {
+				merged.Content = append(merged.Content, item)
+			}
+		}
+		return merged
+	}
+
+	return override
+}
+
+func (inc *includer) copy(n *yaml.Node) *yaml.Node {
+	c := *n
+	inc.origins[&c] = inc.origins[n]
+	return &c
+}
+
+// isNamedList : checks if all elements of a list are maps with a name
+func isNamedList(n *yaml.Node) bool {
+	for _, item := range n.Content {
+		if item.Kind != yaml.MappingNode {
+			return false
+		}
+		if name := mappingValue(item, "name"); name == nil || name.Kind != yaml.ScalarNode {
+			return false
+		}
+	}
+	return true
+}
+
+func mappingIndex(n *yaml.Node, key string) int {
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+func namedIndex(n *yaml.Node, name string) int {
+	for i, item := range n.Content {
+		if mappingValue(item, "name").Value == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// rebaseImports : makes the files referenced by an included file relative
+// to it, as it is merged into a definition on another directory
+func rebaseImports(n *yaml.Node, file string) {
+	if ref, ok := importRef(n.Value); ok && n.Kind == yaml.ScalarNode {
+		n.Value = "@{" + ImportPath(file, ref) + "}"
+	}
+	for _, child := range n.Content {
+		rebaseImports(child, file)
+	}
+}
+
+// displayPath : shows paths relative to the working directory when possible
+func displayPath(path string) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return path
+	}
+	if rel, err := filepath.Rel(wd, path); err == nil && !strings.HasPrefix(rel, "..") {
+		return rel
+	}
+	return path
+}
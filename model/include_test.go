@@ -0,0 +1,241 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package model
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestLoadIncludes(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		confine bool
+		want    string
+		err     string
+	}{
+		{
+			name: "maps are deep merged",
+			files: map[string]string{
+				"main.yml": "include: base.yml\nname: app\nnetwork:\n  subnet: 10.0.1.0/24\n",
+				"base.yml": "project: p\nnetwork:\n  subnet: 10.0.0.0/24\n  nat: true\n",
+			},
+			want: "name: app\nnetwork:\n  subnet: 10.0.1.0/24\n  nat: true\nproject: p\n",
+		},
+		{
+			name: "named lists are merged by name",
+			files: map[string]string{
+				"main.yml": "include: base.yml\nservers:\n- name: web\n  count: 2\n- name: db\n",
+				"base.yml": "servers:\n- name: web\n  count: 1\n  size: small\n",
+			},
+			want: "servers:\n- name: web\n  count: 2\n  size: small\n- name: db\n",
+		},
+		{
+			name: "later includes override earlier ones",
+			files: map[string]string{
+				"main.yml": "include: [a.yml, b.yml]\nname: app\n",
+				"a.yml":    "size: small\n",
+				"b.yml":    "size: large\n",
+			},
+			want: "name: app\nsize: large\n",
+		},
+		{
+			name: "plain lists are replaced",
+			files: map[string]string{
+				"main.yml": "include: base.yml\nports: [443]\n",
+				"base.yml": "ports: [80, 22]\n",
+			},
+			want: "ports:\n- 443\n",
+		},
+		{
+			name: "an empty list clears the included one",
+			files: map[string]string{
+				"main.yml": "include: base.yml\nservers: []\n",
+				"base.yml": "servers:\n- name: web\n",
+			},
+			want: "servers: []\n",
+		},
+		{
+			name: "nested includes are relative to the including file",
+			files: map[string]string{
+				"main.yml":       "include: lib/base.yml\nname: app\n",
+				"lib/base.yml":   "include: common.yml\n",
+				"lib/common.yml": "project: p\n",
+			},
+			want: "name: app\nproject: p\n",
+		},
+		{
+			name: "cycles are detected",
+			files: map[string]string{
+				"main.yml": "include: a.yml\n",
+				"a.yml":    "include: b.yml\n",
+				"b.yml":    "include: a.yml\n",
+			},
+			err: "include cycle detected",
+		},
+		{
+			name: "missing files fail",
+			files: map[string]string{
+				"main.yml": "include: missing.yml\n",
+			},
+			err: "can't access included file missing.yml",
+		},
+		{
+			name: "included files must be maps",
+			files: map[string]string{
+				"main.yml": "include: list.yml\n",
+				"list.yml": "- a\n",
+			},
+			err: "included file list.yml should be a map",
+		},
+		{
+			name: "confined includes stay inside the definition directory",
+			files: map[string]string{
+				"app/main.yml": "include: ../base.yml\n",
+				"base.yml":     "project: p\n",
+			},
+			confine: true,
+			err:     "included file ../base.yml is outside of the definition directory",
+		},
+		{
+			name: "unconfined includes may leave the definition directory",
+			files: map[string]string{
+				"app/main.yml": "include: ../base.yml\nname: app\n",
+				"base.yml":     "project: p\n",
+			},
+			want: "name: app\nproject: p\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "ernest-include")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+			writeFiles(t, dir, tt.files)
+
+			path := filepath.Join(dir, "main.yml")
+			if _, ok := tt.files["app/main.yml"]; ok {
+				path = filepath.Join(dir, "app", "main.yml")
+			}
+			payload, err := ioutil.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			d := Definition{Path: path, Confine: tt.confine}
+			if err := d.Load(payload); err != nil {
+				t.Fatal(err)
+			}
+			err = d.LoadIncludes()
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("expected error %q, got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := d.Save()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("got\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefinitionOverride(t *testing.T) {
+	vars := Variables{"size": "large", "count": "3"}
+
+	tests := []struct {
+		name      string
+		base      string
+		overrides string
+		want      string
+		err       string
+	}{
+		{
+			name:      "only the overrides are interpolated",
+			base:      "name: web\nscript: echo ${var.size}\nsize: small\n",
+			overrides: "size: ${var.size}\ncount: ${int:var.count}\n",
+			want:      "size: large\ncount: 3\nname: web\nscript: echo ${var.size}\n",
+		},
+		{
+			name:      "named lists are merged by name",
+			base:      "servers:\n- name: web\n  size: small\n",
+			overrides: "servers:\n- name: web\n  size: ${var.size}\n",
+			want:      "servers:\n- name: web\n  size: large\n",
+		},
+		{
+			name:      "undefined variables on the overrides fail",
+			base:      "name: web\n",
+			overrides: "size: ${var.missing}\n",
+			err:       "Undefined variables on definition: var.missing",
+		},
+		{
+			name:      "overrides must be a map",
+			base:      "name: web\n",
+			overrides: "- a\n",
+			err:       "should be a map",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "ernest-override")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+			writeFiles(t, dir, map[string]string{"overrides.yml": tt.overrides})
+
+			var d Definition
+			if err := d.Load([]byte(tt.base)); err != nil {
+				t.Fatal(err)
+			}
+			err = d.Override(filepath.Join(dir, "overrides.yml"), vars)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("expected error %q, got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := d.Save()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("got\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
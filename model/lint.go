@@ -0,0 +1,394 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package model
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+const (
+	// LintError : the definition will be rejected
+	LintError = "error"
+	// LintWarning : the definition is accepted, but probably not as intended
+	LintWarning = "warning"
+)
+
+// LintIssue : a problem found on a definition, with its position
+type LintIssue struct {
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// String : formats the issue as file:line:column: severity: message
+func (i LintIssue) String() string {
+	pos := fmt.Sprintf("%s:%d", i.File, i.Line)
+	if i.Column > 0 {
+		pos = fmt.Sprintf("%s:%d", pos, i.Column)
+	}
+	return fmt.Sprintf("%s: %s: %s", pos, i.Severity, i.Message)
+}
+
+// LintResult : issues found linting a definition file
+type LintResult struct {
+	File     string      `json:"file"`
+	Provider string      `json:"provider"`
+	Issues   []LintIssue `json:"issues"`
+}
+
+// Errors : number of issues with error severity
+func (r *LintResult) Errors() int {
+	return r.count(LintError)
+}
+
+// Warnings : number of issues with warning severity
+func (r *LintResult) Warnings() int {
+	return r.count(LintWarning)
+}
+
+func (r *LintResult) count(severity string) (n int) {
+	for _, i := range r.Issues {
+		if i.Severity == severity {
+			n++
+		}
+	}
+	return
+}
+
+var yamlErrorLine = regexp.MustCompile(`line (\d+): `)
+
+// LintOptions : options to lint a definition
+type LintOptions struct {
+	// Provider whose schema is used, inferred when empty
+	Provider string
+	// Variables interpolated before validating the values
+	Variables Variables
+	// Confine rejects referenced files outside of the definition directory
+	Confine bool
+}
+
+// Lint : validates a definition against the bundled schema of a provider,
+// when no provider is specified the one recognizing most of the definition
+// fields is used
+func Lint(file string, payload []byte, opts LintOptions) (*LintResult, error) {
+	provider := opts.Provider
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(payload, &doc); err != nil {
+		issue := syntaxIssue(file, payload, err)
+		return &LintResult{File: file, Provider: provider, Issues: []LintIssue{issue}}, nil
+	}
+
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return &LintResult{File: file, Provider: provider, Issues: []LintIssue{
+			{File: file, Line: 1, Severity: LintError, Message: "definition is empty"},
+		}}, nil
+	}
+
+	root, origins, err := resolveIncludes(file, doc.Content[0], opts.Confine)
+	if err != nil {
+		issue := LintIssue{File: file, Line: 1, Severity: LintError, Message: err.Error()}
+		if e, ok := err.(*IncludeError); ok {
+			issue = LintIssue{File: e.File, Line: e.Line, Column: e.Column, Severity: LintError, Message: e.Message}
+		}
+		return &LintResult{File: file, Provider: provider, Issues: []LintIssue{issue}}, nil
+	}
+
+	undefined := interpolateNodes(file, root, opts.Variables, origins)
+
+	if provider != "" {
+		schema, ok := Schemas[provider]
+		if !ok {
+			return nil, errors.New("Unknown provider '" + provider + "', valid providers are " + strings.Join(Providers(), ", "))
+		}
+		return lintWith(file, root, provider, schema, undefined, origins, opts.Confine), nil
+	}
+
+	var results []*LintResult
+	for _, name := range Providers() {
+		results = append(results, lintWith(file, root, name, Schemas[name], undefined, origins, opts.Confine))
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return lessIssues(results[i], results[j])
+	})
+	if len(results) > 1 && !lessIssues(results[0], results[1]) {
+		return nil, errors.New("Could not infer the provider of the definition, please specify it with --provider")
+	}
+
+	return results[0], nil
+}
+
+// syntaxIssue : creates the issue of a yaml syntax error. The parser
+// reports the line where the failing block starts, so the position is
+// narrowed to the shortest part of the definition failing the same way
+func syntaxIssue(file string, payload []byte, err error) LintIssue {
+	issue := LintIssue{File: file, Severity: LintError, Message: err.Error()}
+	m := yamlErrorLine.FindStringSubmatch(err.Error())
+	if m == nil {
+		return issue
+	}
+	issue.Line, _ = strconv.Atoi(m[1])
+	issue.Message = strings.TrimPrefix(err.Error(), "yaml: "+m[0])
+
+	fails := func(n int) bool {
+		var doc yaml.Node
+		err := yaml.Unmarshal(payload[:n], &doc)
+		return err != nil && strings.HasSuffix(err.Error(), ": "+issue.Message)
+	}
+
+	start := 0
+	for i, line := range bytes.SplitAfter(payload, []byte("\n")) {
+		end := start + len(line)
+		if i+1 < issue.Line || !fails(end) {
+			start = end
+			continue
+		}
+		for offset, column := 0, 1; offset < len(line); column++ {
+			_, size := utf8.DecodeRune(line[offset:])
+			offset += size
+			if fails(start + offset) {
+				issue.Line, issue.Column = i+1, column
+				return issue
+			}
+		}
+		break
+	}
+
+	return issue
+}
+
+func lessIssues(a, b *LintResult) bool {
+	if a.Warnings() != b.Warnings() {
+		return a.Warnings() < b.Warnings()
+	}
+	return a.Errors() < b.Errors()
+}
+
+func lintWith(file string, root *yaml.Node, provider string, schema *SchemaField, issues []LintIssue, origins map[*yaml.Node]string, confine bool) *LintResult {
+	l := linter{
+		result:  &LintResult{File: file, Provider: provider, Issues: append([]LintIssue{}, issues...)},
+		origins: origins,
+		confine: confine,
+	}
+	l.check(root, schema, "definition")
+	sort.SliceStable(l.result.Issues, func(i, j int) bool {
+		a, b := l.result.Issues[i], l.result.Issues[j]
+		if a.File != b.File {
+			if a.File == file || b.File == file {
+				return a.File == file
+			}
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return l.result
+}
+
+// interpolateNodes : replaces variable references on all scalar values,
+// reporting the undefined ones
+func interpolateNodes(file string, n *yaml.Node, vars Variables, origins map[*yaml.Node]string) []LintIssue {
+	var issues []LintIssue
+
+	if n.Kind == yaml.ScalarNode {
+		typ := ReferenceType(n.Value)
+		value, undefined := vars.Interpolate(n.Value)
+		messages := make([]string, 0, len(undefined))
+		for _, name := range undefined {
+			messages = append(messages, "undefined variable "+name)
+		}
+		if typ != "" && len(undefined) == 0 {
+			if _, err := typedValue(typ, value); err != nil {
+				messages = append(messages, err.Error())
+			} else {
+				n.Tag = "!!" + typ
+			}
+		}
+		for _, msg := range messages {
+			issues = append(issues, LintIssue{
+				File:     nodeFile(file, n, origins),
+				Line:     n.Line,
+				Column:   n.Column,
+				Severity: LintError,
+				Message:  msg,
+			})
+		}
+		n.Value = value
+	}
+
+	for _, child := range n.Content {
+		issues = append(issues, interpolateNodes(file, child, vars, origins)...)
+	}
+
+	return issues
+}
+
+// nodeFile : gets the file a node was loaded from
+func nodeFile(file string, n *yaml.Node, origins map[*yaml.Node]string) string {
+	if origin, ok := origins[n]; ok {
+		return origin
+	}
+	return file
+}
+
+type linter struct {
+	result  *LintResult
+	origins map[*yaml.Node]string
+	confine bool
+}
+
+func (l *linter) add(n *yaml.Node, severity, format string, args ...interface{}) {
+	l.result.Issues = append(l.result.Issues, LintIssue{
+		File:     nodeFile(l.result.File, n, l.origins),
+		Line:     n.Line,
+		Column:   n.Column,
+		Severity: severity,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (l *linter) check(n *yaml.Node, field *SchemaField, path string) {
+	if n.Kind == yaml.AliasNode {
+		n = n.Alias
+	}
+
+	switch field.Type {
+	case TypeString:
+		if n.Kind != yaml.ScalarNode {
+			l.add(n, LintError, "%s should be a string", path)
+			return
+		}
+		if ref, ok := importRef(n.Value); ok {
+			if _, err := CheckImport(l.result.File, ref, l.confine); err != nil {
+				l.add(n, LintError, "%s", err.Error())
+			}
+		}
+	case TypeInt:
+		if _, err := strconv.Atoi(n.Value); n.Kind != yaml.ScalarNode || err != nil {
+			l.add(n, LintError, "%s should be an integer", path)
+		}
+	case TypeBool:
+		if n.Kind != yaml.ScalarNode || !isBool(n) {
+			l.add(n, LintError, "%s should be a boolean", path)
+		}
+	case TypeStringList:
+		if n.Kind != yaml.SequenceNode {
+			l.add(n, LintError, "%s should be a list of strings", path)
+			return
+		}
+		for i, item := range n.Content {
+			l.check(item, str(), fmt.Sprintf("%s[%d]", path, i))
+		}
+	case TypeMap:
+		if n.Kind != yaml.MappingNode {
+			l.add(n, LintError, "%s should be a map", path)
+			return
+		}
+		if field.Fields != nil {
+			l.checkFields(n, field.Fields, path)
+		}
+	case TypeList:
+		if n.Kind != yaml.SequenceNode {
+			l.add(n, LintError, "%s should be a list", path)
+			return
+		}
+		names := make(map[string]bool)
+		for i, item := range n.Content {
+			itemPath := fmt.Sprintf("%s[%d]", path, i)
+			if item.Kind != yaml.MappingNode {
+				l.add(item, LintError, "%s should be a map", itemPath)
+				continue
+			}
+			if name := mappingValue(item, "name"); name != nil && name.Kind == yaml.ScalarNode && name.Value != "" {
+				if names[name.Value] {
+					l.add(name, LintError, "%s name '%s' is duplicated", path, name.Value)
+				}
+				names[name.Value] = true
+			}
+			l.checkFields(item, field.Fields, itemPath)
+		}
+	}
+}
+
+func (l *linter) checkFields(n *yaml.Node, fields map[string]*SchemaField, path string) {
+	seen := make(map[string]bool)
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, value := n.Content[i], n.Content[i+1]
+		if key.Value == "<<" {
+			continue
+		}
+
+		fieldPath := key.Value
+		if path != "definition" {
+			fieldPath = path + "." + key.Value
+		}
+
+		if seen[key.Value] {
+			l.add(key, LintError, "%s is defined more than once", fieldPath)
+		}
+		seen[key.Value] = true
+
+		field, ok := fields[key.Value]
+		if !ok {
+			l.add(key, LintWarning, "unknown field %s", fieldPath)
+			continue
+		}
+		if isNull(value) {
+			if field.Required {
+				l.add(key, LintError, "%s is required", fieldPath)
+			}
+			continue
+		}
+		l.check(value, field, fieldPath)
+	}
+
+	required := make([]string, 0)
+	for name, field := range fields {
+		if field.Required && !seen[name] {
+			required = append(required, name)
+		}
+	}
+	sort.Strings(required)
+	for _, name := range required {
+		l.add(n, LintError, "%s is required on %s", name, path)
+	}
+}
+
+func mappingValue(n *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return n.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func isNull(n *yaml.Node) bool {
+	return n.Kind == yaml.ScalarNode && n.Tag == "!!null"
+}
+
+func isBool(n *yaml.Node) bool {
+	if n.Tag == "!!bool" {
+		return true
+	}
+	switch strings.ToLower(n.Value) {
+	case "true", "false", "yes", "no", "on", "off":
+		return true
+	}
+	return false
+}
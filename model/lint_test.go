@@ -0,0 +1,188 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+	tests := []struct {
+		name       string
+		definition string
+		want       []string
+	}{
+		{
+			name:       "valid definitions have no issues",
+			definition: "name: app\nproject: p\nvpcs:\n- name: vpc\n  subnet: 10.0.0.0/16\n  auto_remove: true\n",
+		},
+		{
+			name:       "unknown fields",
+			definition: "name: app\nproject: p\nflavour: large\nvpcs:\n- name: vpc\n  colour: red\n",
+			want: []string{
+				"d.yml:3:1: warning: unknown field flavour",
+				"d.yml:6:3: warning: unknown field vpcs[0].colour",
+			},
+		},
+		{
+			name:       "missing required fields",
+			definition: "name: app\nvpcs:\n- subnet: 10.0.0.0/16\n",
+			want: []string{
+				"d.yml:1:1: error: project is required on definition",
+				"d.yml:3:3: error: name is required on vpcs[0]",
+			},
+		},
+		{
+			name:       "null required fields",
+			definition: "name: app\nproject:\n",
+			want: []string{
+				"d.yml:2:1: error: project is required",
+			},
+		},
+		{
+			name:       "type mismatches",
+			definition: "name: app\nproject: p\nvpcs:\n- name: vpc\n  auto_remove: maybe\n  tags: [a]\ninstances:\n- name: web\n  count: two\n  security_groups: web\n",
+			want: []string{
+				"d.yml:5:16: error: vpcs[0].auto_remove should be a boolean",
+				"d.yml:6:9: error: vpcs[0].tags should be a map",
+				"d.yml:9:10: error: instances[0].count should be an integer",
+				"d.yml:10:20: error: instances[0].security_groups should be a list of strings",
+			},
+		},
+		{
+			name:       "duplicate keys",
+			definition: "name: app\nproject: p\nname: other\n",
+			want: []string{
+				"d.yml:3:1: error: name is defined more than once",
+			},
+		},
+		{
+			name:       "duplicate component names",
+			definition: "name: app\nproject: p\nvpcs:\n- name: vpc\n- name: vpc\n",
+			want: []string{
+				"d.yml:5:9: error: vpcs name 'vpc' is duplicated",
+			},
+		},
+		{
+			name:       "misplaced mapping values",
+			definition: "name: app\nproject: p\n  vpcs: x\n",
+			want: []string{
+				"d.yml:3:7: error: mapping values are not allowed in this context",
+			},
+		},
+		{
+			name:       "unexpected list items",
+			definition: "name: app\nproject: p\n- vpcs\n",
+			want: []string{
+				"d.yml:3:1: error: did not find expected key",
+			},
+		},
+		{
+			name:       "unclosed flow lists",
+			definition: "name: app\nproject: p\ninclude: [a.yml\n",
+			want: []string{
+				"d.yml:3:11: error: did not find expected ',' or ']'",
+			},
+		},
+		{
+			name:       "invalid characters",
+			definition: "name: app\nproject: p\nservice_ip: @ip\n",
+			want: []string{
+				"d.yml:3:13: error: found character that cannot start any token",
+			},
+		},
+		{
+			name:       "empty definitions",
+			definition: "",
+			want: []string{
+				"d.yml:1: error: definition is empty",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Lint("d.yml", []byte(tt.definition), LintOptions{Provider: "aws"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []string
+			for _, issue := range result.Issues {
+				got = append(got, issue.String())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLintProvider(t *testing.T) {
+	tests := []struct {
+		name       string
+		definition string
+		provider   string
+		want       string
+		err        string
+	}{
+		{
+			name:       "aws is inferred from its fields",
+			definition: "name: app\nproject: p\nvpcs:\n- name: vpc\n",
+			want:       "aws",
+		},
+		{
+			name:       "azure is inferred from its fields",
+			definition: "name: app\nproject: p\nresource_groups:\n- name: rg\n  location: westeurope\n",
+			want:       "azure",
+		},
+		{
+			name:       "vcloud is inferred from its fields",
+			definition: "name: app\nproject: p\ndatacenter: dc\nrouters:\n- name: r\n",
+			want:       "vcloud",
+		},
+		{
+			name:       "the provider with less unknown fields wins",
+			definition: "name: app\nproject: p\ndatacenter: dc\nvpcs:\n- name: vpc\nnetworks:\n- name: n\n  router: r\n",
+			want:       "vcloud",
+		},
+		{
+			name:       "given providers are not inferred",
+			definition: "name: app\nproject: p\nvpcs:\n- name: vpc\n",
+			provider:   "vcloud",
+			want:       "vcloud",
+		},
+		{
+			name:       "ambiguous definitions fail",
+			definition: "name: app\nproject: p\n",
+			err:        "Could not infer the provider of the definition, please specify it with --provider",
+		},
+		{
+			name:       "unknown providers fail",
+			definition: "name: app\nproject: p\n",
+			provider:   "gcp",
+			err:        "Unknown provider 'gcp', valid providers are aws, azure, vcloud",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Lint("d.yml", []byte(tt.definition), LintOptions{Provider: tt.provider})
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Fatalf("expected error %q, got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Provider != tt.want {
+				t.Errorf("got provider %s, want %s", result.Provider, tt.want)
+			}
+		})
+	}
+}
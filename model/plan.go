@@ -0,0 +1,171 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/r3labs/diff"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// planDefinitionID : component grouping the top level definition values
+// which are not lists of named components
+const planDefinitionID = "definition"
+
+// DiffDefinitions : structurally compares two definitions. Changes are
+// grouped by component as <section>::<name>, so they can be rendered as
+// a build diff. Ignored top level keys are left out of the comparison
+func DiffDefinitions(from, to []byte, ignore ...string) (diff.Changelog, error) {
+	a, err := planComponents(from)
+	if err != nil {
+		return nil, err
+	}
+	b, err := planComponents(to)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range ignore {
+		delete(a[planDefinitionID], key)
+		delete(b[planDefinitionID], key)
+	}
+
+	ids := make([]string, 0)
+	for id := range a {
+		ids = append(ids, id)
+	}
+	for id := range b {
+		if _, ok := a[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	changelog := make(diff.Changelog, 0)
+	for _, id := range ids {
+		changes, err := diff.Diff(a[id], b[id])
+		if err == diff.ErrTypeMismatch {
+			changes = fieldChanges(a[id], b[id])
+		} else if err != nil {
+			return nil, err
+		}
+		sort.SliceStable(changes, func(i, j int) bool {
+			return strings.Join(changes[i].Path, ".") < strings.Join(changes[j].Path, ".")
+		})
+		for _, c := range changes {
+			c.Path = append([]string{id}, c.Path...)
+			changelog = append(changelog, c)
+		}
+	}
+
+	return changelog, nil
+}
+
+// fieldChanges : compares the fields of two components as whole values,
+// used when a field changes its type
+func fieldChanges(a, b map[string]interface{}) diff.Changelog {
+	keys := make([]string, 0)
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	var changes diff.Changelog
+	for _, k := range keys {
+		from, inA := a[k]
+		to, inB := b[k]
+		switch {
+		case !inA:
+			changes.Add(diff.CREATE, []string{k}, nil, to)
+		case !inB:
+			changes.Add(diff.DELETE, []string{k}, from, nil)
+		case !reflect.DeepEqual(from, to):
+			changes.Add(diff.UPDATE, []string{k}, from, to)
+		}
+	}
+	return changes
+}
+
+// planComponents : splits a definition on its components, lists of named
+// maps become one component per item
+func planComponents(payload []byte) (map[string]map[string]interface{}, error) {
+	var data yaml.MapSlice
+	if err := yaml.Unmarshal(payload, &data); err != nil {
+		return nil, errors.New("Could not process definition yaml")
+	}
+
+	components := map[string]map[string]interface{}{
+		planDefinitionID: make(map[string]interface{}),
+	}
+	for _, item := range data {
+		section := fmt.Sprint(item.Key)
+		value := planValue(item.Value)
+
+		list, ok := value.([]interface{})
+		if !ok || len(list) == 0 || !namedItems(list) {
+			components[planDefinitionID][section] = value
+			continue
+		}
+
+		for _, v := range list {
+			fields := v.(map[string]interface{})
+			id := section + "::" + fmt.Sprint(fields["name"])
+			fields["_component_id"] = id
+			components[id] = fields
+		}
+	}
+
+	return components, nil
+}
+
+// planValue : normalizes yaml values so they can be compared, maps are
+// keyed by string and scalars compared by their representation
+func planValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case yaml.MapSlice:
+		m := make(map[string]interface{})
+		for _, item := range v {
+			m[fmt.Sprint(item.Key)] = planValue(item.Value)
+		}
+		return m
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{})
+		for k, item := range v {
+			m[fmt.Sprint(k)] = planValue(item)
+		}
+		return m
+	case []interface{}:
+		l := make([]interface{}, len(v))
+		for i, item := range v {
+			l[i] = planValue(item)
+		}
+		return l
+	case nil:
+		return nil
+	}
+	return fmt.Sprint(value)
+}
+
+func namedItems(list []interface{}) bool {
+	for _, v := range list {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		if _, ok := m["name"].(string); !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,93 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package model
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDiffDefinitions(t *testing.T) {
+	tests := []struct {
+		name   string
+		from   string
+		to     string
+		ignore []string
+		want   []string
+	}{
+		{
+			name: "no changes",
+			from: "name: app\nservers:\n- name: web\n  count: 1\n",
+			to:   "name: app\nservers:\n- name: web\n  count: 1\n",
+			want: []string{},
+		},
+		{
+			name: "updated component field",
+			from: "servers:\n- name: web\n  count: 1\n",
+			to:   "servers:\n- name: web\n  count: 2\n",
+			want: []string{"update servers::web.count"},
+		},
+		{
+			name: "created and deleted components",
+			from: "servers:\n- name: web\n",
+			to:   "servers:\n- name: db\n",
+			want: []string{
+				"create servers::db._component_id",
+				"create servers::db.name",
+				"delete servers::web._component_id",
+				"delete servers::web.name",
+			},
+		},
+		{
+			name: "top level values",
+			from: "name: app\nsize: small\n",
+			to:   "name: app\nsize: large\nregion: eu\n",
+			want: []string{"create definition.region", "update definition.size"},
+		},
+		{
+			name:   "ignored keys",
+			from:   "name: a\nproject: p\n",
+			to:     "name: b\nproject: q\n",
+			ignore: []string{"name", "project"},
+			want:   []string{},
+		},
+		{
+			name: "scalars are compared by their representation",
+			from: "servers:\n- name: web\n  count: 1\n",
+			to:   "servers:\n- name: web\n  count: \"1\"\n",
+			want: []string{},
+		},
+		{
+			name: "fields changing type",
+			from: "servers:\n- name: web\n  ports: 80\n",
+			to:   "servers:\n- name: web\n  ports: [80, 443]\n",
+			want: []string{"update servers::web.ports"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changelog, err := DiffDefinitions([]byte(tt.from), []byte(tt.to), tt.ignore...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := []string{}
+			for _, c := range changelog {
+				got = append(got, c.Type+" "+strings.Join(c.Path, "."))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffDefinitionsInvalid(t *testing.T) {
+	if _, err := DiffDefinitions([]byte("name: [a"), []byte("name: a")); err == nil {
+		t.Error("expected an error for invalid yaml")
+	}
+}
@@ -0,0 +1,431 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package model
+
+import "sort"
+
+const (
+	// TypeString : any scalar value
+	TypeString = "string"
+	// TypeInt : an integer, quoted or not
+	TypeInt = "integer"
+	// TypeBool : a boolean
+	TypeBool = "boolean"
+	// TypeStringList : a list of scalar values
+	TypeStringList = "list of strings"
+	// TypeMap : a map, free form unless its fields are defined
+	TypeMap = "map"
+	// TypeList : a list of maps, usually a component kind
+	TypeList = "list"
+)
+
+// SchemaField : describes a field allowed on a definition
+type SchemaField struct {
+	Type     string
+	Required bool
+	Fields   map[string]*SchemaField
+}
+
+func str() *SchemaField     { return &SchemaField{Type: TypeString} }
+func reqStr() *SchemaField  { return &SchemaField{Type: TypeString, Required: true} }
+func num() *SchemaField     { return &SchemaField{Type: TypeInt} }
+func boolean() *SchemaField { return &SchemaField{Type: TypeBool} }
+func strList() *SchemaField { return &SchemaField{Type: TypeStringList} }
+func tags() *SchemaField    { return &SchemaField{Type: TypeMap} }
+
+func mapOf(fields map[string]*SchemaField) *SchemaField {
+	return &SchemaField{Type: TypeMap, Fields: fields}
+}
+
+// component : a list of named elements, as networks or instances
+func component(fields map[string]*SchemaField) *SchemaField {
+	fields["name"] = reqStr()
+	return &SchemaField{Type: TypeList, Fields: fields}
+}
+
+func listOf(fields map[string]*SchemaField) *SchemaField {
+	return &SchemaField{Type: TypeList, Fields: fields}
+}
+
+func definitionSchema(fields map[string]*SchemaField) *SchemaField {
+	fields[IncludeKey] = strList()
+	fields["name"] = reqStr()
+	fields["project"] = reqStr()
+	return mapOf(fields)
+}
+
+var awsSecurityGroupRule = map[string]*SchemaField{
+	"ip":        str(),
+	"from_port": num(),
+	"to_port":   num(),
+	"protocol":  str(),
+}
+
+var awsBackups = map[string]*SchemaField{
+	"window":    str(),
+	"retention": num(),
+}
+
+var awsSchema = definitionSchema(map[string]*SchemaField{
+	"bootstrapping": str(),
+	"service_ip":    str(),
+	"vpc_id":        str(),
+	"vpc_subnet":    str(),
+	"vpcs": component(map[string]*SchemaField{
+		"subnet":      str(),
+		"vpc_id":      str(),
+		"auto_remove": boolean(),
+		"tags":        tags(),
+	}),
+	"networks": component(map[string]*SchemaField{
+		"vpc":               str(),
+		"subnet":            str(),
+		"public":            boolean(),
+		"availability_zone": str(),
+		"tags":              tags(),
+	}),
+	"instances": component(map[string]*SchemaField{
+		"type":                 str(),
+		"image":                str(),
+		"network":              str(),
+		"start_ip":             str(),
+		"count":                num(),
+		"key_pair":             str(),
+		"user_data":            str(),
+		"security_groups":      strList(),
+		"elastic_ip":           boolean(),
+		"assign_elastic_ip":    boolean(),
+		"iam_instance_profile": str(),
+		"volumes": listOf(map[string]*SchemaField{
+			"volume": str(),
+			"device": str(),
+		}),
+		"tags": tags(),
+	}),
+	"security_groups": component(map[string]*SchemaField{
+		"vpc":     str(),
+		"egress":  listOf(awsSecurityGroupRule),
+		"ingress": listOf(awsSecurityGroupRule),
+		"tags":    tags(),
+	}),
+	"elbs": component(map[string]*SchemaField{
+		"vpc":             str(),
+		"private":         boolean(),
+		"subnets":         strList(),
+		"instances":       strList(),
+		"security_groups": strList(),
+		"listeners": listOf(map[string]*SchemaField{
+			"from_port": num(),
+			"to_port":   num(),
+			"protocol":  str(),
+			"ssl_cert":  str(),
+		}),
+		"tags": tags(),
+	}),
+	"nats": component(map[string]*SchemaField{
+		"public_network":   str(),
+		"private_networks": strList(),
+		"tags":             tags(),
+	}),
+	"s3_buckets": component(map[string]*SchemaField{
+		"acl":             str(),
+		"bucket_location": str(),
+		"grantees": listOf(map[string]*SchemaField{
+			"id":          str(),
+			"type":        str(),
+			"permissions": str(),
+		}),
+		"tags": tags(),
+	}),
+	"route53_zones": component(map[string]*SchemaField{
+		"private": boolean(),
+		"vpc":     str(),
+		"records": listOf(map[string]*SchemaField{
+			"entry":         str(),
+			"type":          str(),
+			"instances":     strList(),
+			"loadbalancers": strList(),
+			"rds_clusters":  strList(),
+			"rds_instances": strList(),
+			"values":        strList(),
+			"ttl":           num(),
+		}),
+		"tags": tags(),
+	}),
+	"rds_clusters": component(map[string]*SchemaField{
+		"engine":             str(),
+		"engine_version":     str(),
+		"port":               num(),
+		"availability_zones": strList(),
+		"security_groups":    strList(),
+		"networks":           strList(),
+		"database_name":      str(),
+		"database_username":  str(),
+		"database_password":  str(),
+		"backups":            mapOf(awsBackups),
+		"maintenance_window": str(),
+		"final_snapshot":     boolean(),
+		"replication_source": str(),
+		"tags":               tags(),
+	}),
+	"rds_instances": component(map[string]*SchemaField{
+		"size":           str(),
+		"engine":         str(),
+		"engine_version": str(),
+		"port":           num(),
+		"cluster":        str(),
+		"public":         boolean(),
+		"multi_az":       boolean(),
+		"promotion_tier": num(),
+		"storage": mapOf(map[string]*SchemaField{
+			"type": str(),
+			"size": num(),
+			"iops": num(),
+		}),
+		"availability_zone":  str(),
+		"security_groups":    strList(),
+		"networks":           strList(),
+		"database_name":      str(),
+		"database_username":  str(),
+		"database_password":  str(),
+		"auto_upgrade":       boolean(),
+		"backups":            mapOf(awsBackups),
+		"maintenance_window": str(),
+		"final_snapshot":     boolean(),
+		"replication_source": str(),
+		"license":            str(),
+		"timezone":           str(),
+		"tags":               tags(),
+	}),
+	"ebs_volumes": component(map[string]*SchemaField{
+		"type":              str(),
+		"size":              num(),
+		"iops":              num(),
+		"availability_zone": str(),
+		"count":             num(),
+		"encrypted":         boolean(),
+		"encryption_key_id": str(),
+		"tags":              tags(),
+	}),
+	"iam_policies": component(map[string]*SchemaField{
+		"path":        str(),
+		"description": str(),
+		"document":    str(),
+	}),
+	"iam_roles": component(map[string]*SchemaField{
+		"path":                   str(),
+		"description":            str(),
+		"policies":               strList(),
+		"assume_policy_document": str(),
+	}),
+	"iam_instance_profiles": component(map[string]*SchemaField{
+		"path":  str(),
+		"roles": strList(),
+	}),
+})
+
+var azureSchema = definitionSchema(map[string]*SchemaField{
+	"resource_groups": component(map[string]*SchemaField{
+		"location": str(),
+		"tags":     tags(),
+		"virtual_networks": component(map[string]*SchemaField{
+			"address_space": strList(),
+			"dns_servers":   strList(),
+			"subnets": component(map[string]*SchemaField{
+				"address_prefix": str(),
+				"security_group": str(),
+			}),
+			"tags": tags(),
+		}),
+		"public_ips": component(map[string]*SchemaField{
+			"public_ip_address_allocation": str(),
+			"domain_name_label":            str(),
+			"idle_timeout_in_minutes":      num(),
+			"tags":                         tags(),
+		}),
+		"security_groups": component(map[string]*SchemaField{
+			"rules": component(map[string]*SchemaField{
+				"description":                str(),
+				"priority":                   num(),
+				"direction":                  str(),
+				"access":                     str(),
+				"protocol":                   str(),
+				"source_port_range":          str(),
+				"destination_port_range":     str(),
+				"source_address_prefix":      str(),
+				"destination_address_prefix": str(),
+			}),
+			"tags": tags(),
+		}),
+		"load_balancers": component(map[string]*SchemaField{
+			"frontend_ip_configurations": component(map[string]*SchemaField{
+				"subnet":                        str(),
+				"public_ip_address_allocation":  str(),
+				"private_ip_address":            str(),
+				"private_ip_address_allocation": str(),
+			}),
+			"backend_address_pools": strList(),
+			"probes": component(map[string]*SchemaField{
+				"port":             num(),
+				"protocol":         str(),
+				"request_path":     str(),
+				"interval":         num(),
+				"maximum_failures": num(),
+			}),
+			"rules": component(map[string]*SchemaField{
+				"frontend_ip_configuration": str(),
+				"backend_address_pool":      str(),
+				"probe":                     str(),
+				"protocol":                  str(),
+				"frontend_port":             num(),
+				"backend_port":              num(),
+				"floating_ip":               boolean(),
+				"idle_timeout":              num(),
+				"load_distribution":         str(),
+			}),
+			"tags": tags(),
+		}),
+		"virtual_machines": component(map[string]*SchemaField{
+			"size":  str(),
+			"count": num(),
+			"image": mapOf(map[string]*SchemaField{
+				"publisher": str(),
+				"offer":     str(),
+				"sku":       str(),
+				"version":   str(),
+			}),
+			"authentication": mapOf(map[string]*SchemaField{
+				"admin_username": str(),
+				"admin_password": str(),
+				"ssh_keys": listOf(map[string]*SchemaField{
+					"path":     str(),
+					"key_data": str(),
+				}),
+				"disable_password_authentication": boolean(),
+			}),
+			"network_interfaces": component(map[string]*SchemaField{
+				"subnet":                              str(),
+				"security_group":                      str(),
+				"private_ip_address_allocation":       str(),
+				"public_ip_address_allocation":        str(),
+				"load_balancer_backend_address_pools": strList(),
+			}),
+			"storage_os_disk": mapOf(map[string]*SchemaField{
+				"name":              str(),
+				"caching":           str(),
+				"create_option":     str(),
+				"managed_disk_type": str(),
+				"os_type":           str(),
+				"storage_account":   str(),
+				"storage_container": str(),
+			}),
+			"delete_os_disk_on_termination": boolean(),
+			"availability_set":              str(),
+			"boot_diagnostics":              tags(),
+			"tags":                          tags(),
+		}),
+		"storage_accounts": component(map[string]*SchemaField{
+			"tier":             str(),
+			"replication_type": str(),
+			"account_kind":     str(),
+			"containers": component(map[string]*SchemaField{
+				"access_type": str(),
+			}),
+			"tags": tags(),
+		}),
+		"sql_servers": component(map[string]*SchemaField{
+			"version":                      str(),
+			"administrator_login":          str(),
+			"administrator_login_password": str(),
+			"firewall_rules": component(map[string]*SchemaField{
+				"start_ip_address": str(),
+				"end_ip_address":   str(),
+			}),
+			"databases": component(map[string]*SchemaField{
+				"collation":                        str(),
+				"edition":                          str(),
+				"max_size_bytes":                   str(),
+				"requested_service_objective_name": str(),
+				"create_mode":                      str(),
+				"tags":                             tags(),
+			}),
+			"tags": tags(),
+		}),
+		"availability_sets": component(map[string]*SchemaField{
+			"managed":                      boolean(),
+			"platform_fault_domain_count":  num(),
+			"platform_update_domain_count": num(),
+			"tags":                         tags(),
+		}),
+	}),
+})
+
+var vcloudSchema = definitionSchema(map[string]*SchemaField{
+	"datacenter":    str(),
+	"bootstrapping": str(),
+	"service_ip":    str(),
+	"routers": component(map[string]*SchemaField{
+		"rules": component(map[string]*SchemaField{
+			"source":      str(),
+			"from_port":   str(),
+			"destination": str(),
+			"to_port":     str(),
+			"protocol":    str(),
+			"action":      str(),
+		}),
+		"port_forwarding": listOf(map[string]*SchemaField{
+			"from_ip":          str(),
+			"from_port":        str(),
+			"to_ip":            str(),
+			"to_port":          str(),
+			"destination_ip":   str(),
+			"destination_port": str(),
+			"source_port":      str(),
+		}),
+	}),
+	"networks": component(map[string]*SchemaField{
+		"router":        str(),
+		"subnet":        str(),
+		"dns":           strList(),
+		"gateway":       str(),
+		"netmask":       str(),
+		"start_address": str(),
+		"end_address":   str(),
+	}),
+	"instances": component(map[string]*SchemaField{
+		"image":  str(),
+		"cpus":   num(),
+		"memory": str(),
+		"count":  num(),
+		"networks": mapOf(map[string]*SchemaField{
+			"name":     str(),
+			"start_ip": str(),
+		}),
+		"disks": listOf(map[string]*SchemaField{
+			"id":   num(),
+			"size": str(),
+		}),
+		"provisioner": listOf(map[string]*SchemaField{
+			"shell": strList(),
+		}),
+	}),
+})
+
+// Schemas : definition schemas bundled for each provider type
+var Schemas = map[string]*SchemaField{
+	"aws":    awsSchema,
+	"azure":  azureSchema,
+	"vcloud": vcloudSchema,
+}
+
+// Providers : sorted list of providers with a bundled schema
+func Providers() []string {
+	providers := make([]string, 0, len(Schemas))
+	for name := range Schemas {
+		providers = append(providers, name)
+	}
+	sort.Strings(providers)
+	return providers
+}
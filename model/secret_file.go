@@ -0,0 +1,191 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package model
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// scrypt parameters deriving the file key from the passphrase
+const (
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+)
+
+// SecretsPassphrase : asks for the passphrase of the encrypted secrets
+// file, confirming it when the file is created. It is only used when the
+// ERNEST_SECRETS_PASSPHRASE environment variable is not set
+var SecretsPassphrase = func(create bool) ([]byte, error) {
+	return nil, errors.New("Please set the ERNEST_SECRETS_PASSPHRASE environment variable")
+}
+
+// fileStore : keeps secrets on a file encrypted with AES-256-GCM, using a
+// key derived from a passphrase with scrypt
+type fileStore struct {
+	path       string
+	passphrase []byte
+	secrets    map[string]string
+}
+
+// secretFile : content of the encrypted secrets file
+type secretFile struct {
+	Salt  []byte `json:"salt"`
+	Nonce []byte `json:"nonce"`
+	Data  []byte `json:"data"`
+}
+
+// Get : reads a secret from the file
+func (s *fileStore) Get(profile, key string) (string, error) {
+	if err := s.load(); err != nil {
+		return "", err
+	}
+	value, ok := s.secrets[profile+"/"+key]
+	if !ok {
+		return "", ErrSecretNotFound
+	}
+	return value, nil
+}
+
+// Set : writes a secret to the file
+func (s *fileStore) Set(profile, key, value string) error {
+	if err := s.load(); err != nil {
+		return err
+	}
+	s.secrets[profile+"/"+key] = value
+	return s.save()
+}
+
+// Delete : removes a secret from the file
+func (s *fileStore) Delete(profile, key string) error {
+	if err := s.load(); err != nil {
+		return err
+	}
+	if _, ok := s.secrets[profile+"/"+key]; !ok {
+		return ErrSecretNotFound
+	}
+	delete(s.secrets, profile+"/"+key)
+	return s.save()
+}
+
+// load : decrypts the file once, a missing file has no secrets
+func (s *fileStore) load() error {
+	if s.secrets != nil {
+		return nil
+	}
+
+	payload, err := ioutil.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		s.secrets = make(map[string]string)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var f secretFile
+	if err = json.Unmarshal(payload, &f); err != nil {
+		return errors.New("Secrets file " + s.path + " is invalid")
+	}
+	if err = s.askPassphrase(false); err != nil {
+		return err
+	}
+	gcm, err := s.cipher(f.Salt)
+	if err != nil {
+		return err
+	}
+	data, err := gcm.Open(nil, f.Nonce, f.Data, nil)
+	if err != nil {
+		return errors.New("Can't decrypt the secrets file, is the passphrase correct?")
+	}
+
+	secrets := make(map[string]string)
+	if err = json.Unmarshal(data, &secrets); err != nil {
+		return errors.New("Secrets file " + s.path + " is invalid")
+	}
+	s.secrets = secrets
+
+	return nil
+}
+
+// save : encrypts the secrets with a new salt and nonce on every write
+func (s *fileStore) save() error {
+	if err := s.askPassphrase(true); err != nil {
+		return err
+	}
+
+	f := secretFile{
+		Salt: make([]byte, 32),
+	}
+	if _, err := io.ReadFull(rand.Reader, f.Salt); err != nil {
+		return err
+	}
+	gcm, err := s.cipher(f.Salt)
+	if err != nil {
+		return err
+	}
+	f.Nonce = make([]byte, gcm.NonceSize())
+	if _, err = io.ReadFull(rand.Reader, f.Nonce); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(s.secrets)
+	if err != nil {
+		return err
+	}
+	f.Data = gcm.Seal(nil, f.Nonce, data, nil)
+
+	body, err := json.Marshal(f)
+	if err != nil {
+		return err
+	}
+	if err = ioutil.WriteFile(s.path, body, 0600); err != nil {
+		return errors.New("Can't save secrets file " + s.path)
+	}
+
+	return nil
+}
+
+func (s *fileStore) askPassphrase(create bool) error {
+	if s.passphrase != nil {
+		return nil
+	}
+	if env := os.Getenv("ERNEST_SECRETS_PASSPHRASE"); env != "" {
+		s.passphrase = []byte(env)
+		return nil
+	}
+
+	passphrase, err := SecretsPassphrase(create)
+	if err != nil {
+		return err
+	}
+	if len(passphrase) == 0 {
+		return errors.New("The secrets passphrase can't be empty")
+	}
+	s.passphrase = passphrase
+
+	return nil
+}
+
+func (s *fileStore) cipher(salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key(s.passphrase, salt, scryptN, scryptR, scryptP, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
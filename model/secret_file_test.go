@@ -0,0 +1,151 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package model
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func tempSecretsFile(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "ernest-secrets")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return filepath.Join(dir, ".ernest-secrets"), func() { os.RemoveAll(dir) }
+}
+
+func TestFileStoreRoundTrip(t *testing.T) {
+	path, cleanup := tempSecretsFile(t)
+	defer cleanup()
+
+	s := &fileStore{path: path, passphrase: []byte("correct horse")}
+	if _, err := s.Get("default", "token"); err != ErrSecretNotFound {
+		t.Fatalf("expected a missing file to have no secrets, got %v", err)
+	}
+	if err := s.Set("default", "token", "s3cr3t-token"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Set("staging", "token", "other-token"); err != nil {
+		t.Fatal(err)
+	}
+
+	payload, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(payload), "s3cr3t-token") {
+		t.Error("secrets file holds the token in plaintext")
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("expected secrets file mode 0600, got %v %v", info.Mode().Perm(), err)
+	}
+
+	reopened := &fileStore{path: path, passphrase: []byte("correct horse")}
+	for profile, want := range map[string]string{"default": "s3cr3t-token", "staging": "other-token"} {
+		if got, err := reopened.Get(profile, "token"); err != nil || got != want {
+			t.Errorf("Get(%s) = %q, %v, want %q", profile, got, err, want)
+		}
+	}
+
+	if err := reopened.Delete("staging", "token"); err != nil {
+		t.Fatal(err)
+	}
+	reopened = &fileStore{path: path, passphrase: []byte("correct horse")}
+	if _, err := reopened.Get("staging", "token"); err != ErrSecretNotFound {
+		t.Errorf("expected deleted secret to be missing, got %v", err)
+	}
+	if err := reopened.Delete("staging", "token"); err != ErrSecretNotFound {
+		t.Errorf("expected deleting a missing secret to fail, got %v", err)
+	}
+}
+
+func TestFileStoreInvalid(t *testing.T) {
+	path, cleanup := tempSecretsFile(t)
+	defer cleanup()
+
+	s := &fileStore{path: path, passphrase: []byte("correct horse")}
+	if err := s.Set("default", "token", "s3cr3t-token"); err != nil {
+		t.Fatal(err)
+	}
+	payload, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var f secretFile
+	if err = json.Unmarshal(payload, &f); err != nil {
+		t.Fatal(err)
+	}
+	corrupt := func(change func(f *secretFile)) []byte {
+		c := secretFile{
+			Salt:  append([]byte{}, f.Salt...),
+			Nonce: append([]byte{}, f.Nonce...),
+			Data:  append([]byte{}, f.Data...),
+		}
+		change(&c)
+		body, err := json.Marshal(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return body
+	}
+
+	decrypt := "Can't decrypt the secrets file, is the passphrase correct?"
+	invalid := "Secrets file " + path + " is invalid"
+
+	tests := []struct {
+		name       string
+		payload    []byte
+		passphrase string
+		err        string
+	}{
+		{"wrong passphrase", payload, "wrong horse", decrypt},
+		{"corrupted data", corrupt(func(f *secretFile) { f.Data[0] ^= 0xff }), "correct horse", decrypt},
+		{"truncated data", corrupt(func(f *secretFile) { f.Data = f.Data[:len(f.Data)/2] }), "correct horse", decrypt},
+		{"corrupted salt", corrupt(func(f *secretFile) { f.Salt[0] ^= 0xff }), "correct horse", decrypt},
+		{"truncated file", payload[:len(payload)/2], "correct horse", invalid},
+		{"empty file", []byte{}, "correct horse", invalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ioutil.WriteFile(path, tt.payload, 0600); err != nil {
+				t.Fatal(err)
+			}
+			s := &fileStore{path: path, passphrase: []byte(tt.passphrase)}
+			if _, err := s.Get("default", "token"); err == nil || err.Error() != tt.err {
+				t.Errorf("expected error %q, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestFileStorePassphrase(t *testing.T) {
+	path, cleanup := tempSecretsFile(t)
+	defer cleanup()
+
+	defer os.Unsetenv("ERNEST_SECRETS_PASSPHRASE")
+	os.Unsetenv("ERNEST_SECRETS_PASSPHRASE")
+
+	s := &fileStore{path: path}
+	if err := s.Set("default", "token", "t"); err == nil || err.Error() != "Please set the ERNEST_SECRETS_PASSPHRASE environment variable" {
+		t.Errorf("expected the passphrase to be required, got %v", err)
+	}
+
+	os.Setenv("ERNEST_SECRETS_PASSPHRASE", "correct horse")
+	s = &fileStore{path: path}
+	if err := s.Set("default", "token", "t"); err != nil {
+		t.Fatal(err)
+	}
+	s = &fileStore{path: path, passphrase: []byte("correct horse")}
+	if got, err := s.Get("default", "token"); err != nil || got != "t" {
+		t.Errorf("Get = %q, %v, want %q", got, err, "t")
+	}
+}
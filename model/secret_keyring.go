@@ -0,0 +1,114 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package model
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// keyringStore : keeps secrets on the operating system keyring, the
+// macOS keychain through security and the Secret Service through
+// secret-tool on linux. Secrets are passed to both tools on stdin, so
+// they are never visible on the process list
+type keyringStore struct {
+	service string
+}
+
+// Get : reads a secret from the keyring
+func (s *keyringStore) Get(profile, key string) (string, error) {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("security", "find-generic-password", "-s", s.service, "-a", s.account(profile, key), "-w")
+	case "linux", "freebsd", "openbsd":
+		cmd = exec.Command("secret-tool", "lookup", "service", s.service, "account", s.account(profile, key))
+	default:
+		return "", s.unsupported()
+	}
+
+	out, err := s.run(cmd, nil)
+	if err != nil {
+		return "", err
+	}
+	if len(out) == 0 {
+		return "", ErrSecretNotFound
+	}
+
+	return strings.TrimSuffix(string(out), "\n"), nil
+}
+
+// Set : writes a secret to the keyring, replacing any existing one
+func (s *keyringStore) Set(profile, key, value string) error {
+	var cmd *exec.Cmd
+	var stdin []byte
+	switch runtime.GOOS {
+	case "darwin":
+		// interactive mode reads the command from stdin, the password is
+		// hex encoded to avoid quoting it
+		cmd = exec.Command("security", "-i")
+		stdin = []byte(fmt.Sprintf("add-generic-password -U -s %q -a %q -X %s\n", s.service, s.account(profile, key), hex.EncodeToString([]byte(value))))
+	case "linux", "freebsd", "openbsd":
+		cmd = exec.Command("secret-tool", "store", "--label", "ernest "+s.account(profile, key), "service", s.service, "account", s.account(profile, key))
+		stdin = []byte(value)
+	default:
+		return s.unsupported()
+	}
+
+	_, err := s.run(cmd, stdin)
+	return err
+}
+
+// Delete : removes a secret from the keyring
+func (s *keyringStore) Delete(profile, key string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("security", "delete-generic-password", "-s", s.service, "-a", s.account(profile, key))
+	case "linux", "freebsd", "openbsd":
+		cmd = exec.Command("secret-tool", "clear", "service", s.service, "account", s.account(profile, key))
+	default:
+		return s.unsupported()
+	}
+
+	_, err := s.run(cmd, nil)
+	return err
+}
+
+func (s *keyringStore) account(profile, key string) string {
+	return profile + "/" + key
+}
+
+// run : runs a keyring tool, missing secrets are reported by both tools
+// with a non zero exit code and no error output
+func (s *keyringStore) run(cmd *exec.Cmd, stdin []byte) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd.Stdin = bytes.NewReader(stdin)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if _, ok := err.(*exec.ExitError); ok {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" || strings.Contains(msg, "could not be found") {
+			return nil, ErrSecretNotFound
+		}
+		return nil, errors.New(msg)
+	}
+	if err != nil {
+		return nil, errors.New("Can't run " + cmd.Args[0] + ", is the keyring available? " + err.Error())
+	}
+
+	return stdout.Bytes(), nil
+}
+
+func (s *keyringStore) unsupported() error {
+	return errors.New("The keyring secret store is not supported on " + runtime.GOOS + ", use the file store instead")
+}
@@ -0,0 +1,176 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package model
+
+import (
+	"errors"
+	"strings"
+)
+
+const (
+	// SecretStorePlain : secrets are kept on the .ernest file, as done by
+	// previous versions
+	SecretStorePlain = "plaintext"
+	// SecretStoreKeyring : secrets are kept on the operating system keyring
+	SecretStoreKeyring = "keyring"
+	// SecretStoreFile : secrets are kept on a passphrase encrypted file
+	SecretStoreFile = "file"
+)
+
+// secretToken : key the session token of a profile is stored as
+const secretToken = "token"
+
+// ErrSecretNotFound : the secret is not on the store
+var ErrSecretNotFound = errors.New("secret not found")
+
+// SecretStore : keeps the secrets of the configured profiles out of the
+// .ernest file
+type SecretStore interface {
+	Get(profile, key string) (string, error)
+	Set(profile, key, value string) error
+	Delete(profile, key string) error
+}
+
+// secretStores : stores in use, so each of them is opened once
+var secretStores = map[string]SecretStore{}
+
+// GetSecretStore : gets a secret store by name, an empty name being the
+// plaintext store
+func GetSecretStore(name string) (SecretStore, error) {
+	if name == "" {
+		name = SecretStorePlain
+	}
+	if s, ok := secretStores[name]; ok {
+		return s, nil
+	}
+
+	var s SecretStore
+	switch name {
+	case SecretStorePlain:
+		return nil, nil
+	case SecretStoreKeyring:
+		s = &keyringStore{service: "ernest"}
+	case SecretStoreFile:
+		s = &fileStore{path: getConfigPath() + "-secrets"}
+	default:
+		return nil, errors.New("Unsupported secret store '" + name + "', valid stores are " + strings.Join(SecretStoreNames(), ", "))
+	}
+	secretStores[name] = s
+
+	return s, nil
+}
+
+// SecretStoreNames : lists the supported secret stores
+func SecretStoreNames() []string {
+	return []string{SecretStorePlain, SecretStoreKeyring, SecretStoreFile}
+}
+
+// Secrets : gets the name of the store keeping the secrets of the config
+func (c *Config) Secrets() string {
+	if c.SecretStore == "" {
+		return SecretStorePlain
+	}
+	return c.SecretStore
+}
+
+// loadSecrets : reads the token of the config from its secret store
+func (c *Config) loadSecrets() error {
+	store, err := GetSecretStore(c.SecretStore)
+	if err != nil || store == nil {
+		return err
+	}
+
+	token, err := store.Get(c.Name, secretToken)
+	if err != nil && err != ErrSecretNotFound {
+		return err
+	}
+	c.Token = token
+	c.storedToken = token
+
+	return nil
+}
+
+// saveSecrets : writes the token of the config to its secret store when
+// it changed since it was loaded
+func (c *Config) saveSecrets() error {
+	store, err := GetSecretStore(c.SecretStore)
+	if err != nil || store == nil || c.Token == c.storedToken {
+		return err
+	}
+
+	if c.Token == "" {
+		err = store.Delete(c.Name, secretToken)
+	} else {
+		err = store.Set(c.Name, secretToken, c.Token)
+	}
+	if err != nil && err != ErrSecretNotFound {
+		return errors.New("Can't save the token on the " + c.SecretStore + " secret store: " + err.Error())
+	}
+	c.storedToken = c.Token
+
+	return nil
+}
+
+// MigrateSecrets : moves the secrets of the given profiles to another
+// store, removing them from the store they were kept on
+func (p *Profiles) MigrateSecrets(names []string, store string) error {
+	if _, err := GetSecretStore(store); err != nil {
+		return err
+	}
+
+	previous := make(map[string]*Config)
+	for _, name := range names {
+		c, ok := p.Profiles[name]
+		if !ok {
+			return errors.New("Profile '" + name + "' does not exist")
+		}
+		if c.Secrets() == store {
+			continue
+		}
+		if err := c.loadSecrets(); err != nil {
+			return errors.New("Can't read the token of profile '" + name + "': " + err.Error())
+		}
+		sets := make([]*CredentialSet, 0, len(c.CredentialSets))
+		for setName := range c.CredentialSets {
+			set, err := c.CredentialSet(setName)
+			if err != nil {
+				return err
+			}
+			sets = append(sets, set)
+		}
+
+		old := *c
+		previous[name] = &old
+		c.SecretStore = store
+		if store == SecretStorePlain {
+			c.SecretStore = ""
+		}
+		c.storedToken = ""
+		c.CredentialSets = nil
+		for _, set := range sets {
+			if err := c.SetCredentialSet(set); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := p.Save(); err != nil {
+		return err
+	}
+
+	for _, old := range previous {
+		old.Token = ""
+		if err := old.saveSecrets(); err != nil {
+			return err
+		}
+		for setName := range old.CredentialSets {
+			if err := old.RemoveCredentialSet(setName); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package model
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	homedir "github.com/mitchellh/go-homedir"
+)
+
+// tempHome : points the config files to a temporary home, with no secret
+// stores opened
+func tempHome(t *testing.T) func() {
+	home, err := ioutil.TempDir("", "ernest-home")
+	if err != nil {
+		t.Fatal(err)
+	}
+	previous := os.Getenv("HOME")
+	os.Setenv("HOME", home)
+	homedir.DisableCache = true
+	secretStores = map[string]SecretStore{}
+
+	return func() {
+		os.Setenv("HOME", previous)
+		secretStores = map[string]SecretStore{}
+		os.RemoveAll(home)
+	}
+}
+
+func TestMigrateSecrets(t *testing.T) {
+	defer tempHome(t)()
+	defer os.Unsetenv("ERNEST_SECRETS_PASSPHRASE")
+	os.Setenv("ERNEST_SECRETS_PASSPHRASE", "correct horse")
+
+	values := map[string]interface{}{"aws_access_key_id": "AKIA", "aws_secret_access_key": "s3cr3t-key"}
+	p := &Profiles{Current: "default", Profiles: map[string]*Config{
+		"default": {
+			Name:  "default",
+			URL:   "https://ernest.local",
+			Token: "s3cr3t-token",
+			CredentialSets: map[string]*CredentialSet{
+				"prod": {Provider: "aws", Values: values},
+			},
+		},
+	}}
+	if err := p.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	// plaintext to file
+	if err := GetProfiles().MigrateSecrets([]string{"default"}, SecretStoreFile); err != nil {
+		t.Fatal(err)
+	}
+	config, err := ioutil.ReadFile(getConfigPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"s3cr3t-token", "s3cr3t-key"} {
+		if strings.Contains(string(config), secret) {
+			t.Errorf("config file still holds %s after moving it to the file store", secret)
+		}
+	}
+
+	secretStores = map[string]SecretStore{}
+	c, err := GetConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Secrets() != SecretStoreFile || c.Token != "s3cr3t-token" {
+		t.Errorf("got token %q on the %s store, want it on the file store", c.Token, c.Secrets())
+	}
+	set, err := c.CredentialSet("prod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(set.Values, values) {
+		t.Errorf("got credentials %v, want %v", set.Values, values)
+	}
+
+	// file to plaintext
+	if err := GetProfiles().MigrateSecrets([]string{"default"}, SecretStorePlain); err != nil {
+		t.Fatal(err)
+	}
+	secretStores = map[string]SecretStore{}
+	c, err = GetConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Secrets() != SecretStorePlain || c.Token != "s3cr3t-token" {
+		t.Errorf("got token %q on the %s store, want it on the plaintext store", c.Token, c.Secrets())
+	}
+	if set, err = c.CredentialSet("prod"); err != nil || !reflect.DeepEqual(set.Values, values) {
+		t.Errorf("got credentials %v, %v, want %v", set, err, values)
+	}
+
+	file := &fileStore{path: getConfigPath() + "-secrets"}
+	if _, err := file.Get("default", secretToken); err != ErrSecretNotFound {
+		t.Errorf("expected the token to be removed from the file store, got %v", err)
+	}
+	if _, err := file.Get("default", secretCredentials("prod")); err != ErrSecretNotFound {
+		t.Errorf("expected the credentials to be removed from the file store, got %v", err)
+	}
+}
+
+func TestMigrateSecretsInvalid(t *testing.T) {
+	defer tempHome(t)()
+
+	p := &Profiles{Current: "default", Profiles: map[string]*Config{
+		"default": {Name: "default", Token: "t"},
+	}}
+
+	if err := p.MigrateSecrets([]string{"default"}, "vault"); err == nil || !strings.HasPrefix(err.Error(), "Unsupported secret store 'vault'") {
+		t.Errorf("expected unsupported store error, got %v", err)
+	}
+	if err := p.MigrateSecrets([]string{"missing"}, SecretStoreFile); err == nil || err.Error() != "Profile 'missing' does not exist" {
+		t.Errorf("expected missing profile error, got %v", err)
+	}
+}
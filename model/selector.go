@@ -0,0 +1,83 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package model
+
+import (
+	"errors"
+	"path"
+	"strings"
+)
+
+// EnvSelector : selects environments by project and name, both accepting
+// glob patterns, and by status. Empty fields match any environment
+type EnvSelector struct {
+	Project string
+	Name    string
+	Status  string
+}
+
+// Validate : checks the project and name patterns are valid
+func (s EnvSelector) Validate() error {
+	for _, pattern := range []string{s.Project, s.Name} {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return errors.New("Invalid environment pattern '" + pattern + "'")
+		}
+	}
+	return nil
+}
+
+// Match : checks if an environment is selected
+func (s EnvSelector) Match(project, name, status string) bool {
+	if s.Project != "" {
+		if ok, _ := path.Match(s.Project, project); !ok {
+			return false
+		}
+	}
+	if s.Name != "" {
+		if ok, _ := path.Match(s.Name, name); !ok {
+			return false
+		}
+	}
+	return s.Status == "" || strings.EqualFold(s.Status, status)
+}
+
+// SplitEnvName : gets the project and the short name of an environment.
+// Environments listed across projects are named as project/env, names
+// without a project prefix are returned as given
+func SplitEnvName(project, name string) (string, string) {
+	parts := strings.SplitN(name, "/", 2)
+	if len(parts) != 2 {
+		return project, name
+	}
+	if project == "" {
+		project = parts[0]
+	}
+	if parts[0] != project {
+		return project, name
+	}
+	return project, parts[1]
+}
+
+// IsGlob : checks if a name is a glob pattern
+func IsGlob(name string) bool {
+	return strings.ContainsAny(name, "*?[")
+}
+
+const (
+	// BulkOK : the operation succeeded on the environment
+	BulkOK = "ok"
+	// BulkAwaiting : the operation left the environment awaiting resolution
+	BulkAwaiting = "awaiting_resolution"
+	// BulkFailed : the operation failed on the environment
+	BulkFailed = "failed"
+)
+
+// BulkResult : outcome of an operation run on one of many environments
+type BulkResult struct {
+	Project     string `json:"project"`
+	Environment string `json:"environment"`
+	Result      string `json:"result"`
+	Message     string `json:"message"`
+}
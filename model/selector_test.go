@@ -0,0 +1,82 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package model
+
+import "testing"
+
+func TestSplitEnvName(t *testing.T) {
+	tests := []struct {
+		project, name string
+		wantProject   string
+		wantName      string
+	}{
+		{"p", "p/web", "p", "web"},
+		{"", "p/web", "p", "web"},
+		{"p", "web", "p", "web"},
+		{"p", "other/web", "p", "other/web"},
+	}
+
+	for _, tt := range tests {
+		project, name := SplitEnvName(tt.project, tt.name)
+		if project != tt.wantProject || name != tt.wantName {
+			t.Errorf("SplitEnvName(%q, %q) = %q, %q, want %q, %q", tt.project, tt.name, project, name, tt.wantProject, tt.wantName)
+		}
+	}
+}
+
+func TestEnvSelectorMatch(t *testing.T) {
+	// environments as listed across projects, named project/env
+	envs := []struct {
+		project, name, status string
+	}{
+		{"prod-eu", "prod-eu/web-1", "done"},
+		{"prod-eu", "prod-eu/db", "errored"},
+		{"prod-us", "prod-us/web-2", "done"},
+		{"staging", "staging/web-1", "done"},
+	}
+
+	tests := []struct {
+		name string
+		sel  EnvSelector
+		want []string
+	}{
+		{"everything", EnvSelector{}, []string{"prod-eu/web-1", "prod-eu/db", "prod-us/web-2", "staging/web-1"}},
+		{"project glob", EnvSelector{Project: "prod-*"}, []string{"prod-eu/web-1", "prod-eu/db", "prod-us/web-2"}},
+		{"name glob", EnvSelector{Name: "web-*"}, []string{"prod-eu/web-1", "prod-us/web-2", "staging/web-1"}},
+		{"project and name", EnvSelector{Project: "prod-*", Name: "web-?"}, []string{"prod-eu/web-1", "prod-us/web-2"}},
+		{"exact name", EnvSelector{Project: "prod-eu", Name: "db"}, []string{"prod-eu/db"}},
+		{"status", EnvSelector{Status: "ERRORED"}, []string{"prod-eu/db"}},
+		{"no match", EnvSelector{Name: "api"}, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []string{}
+			for _, e := range envs {
+				project, name := SplitEnvName(e.project, e.name)
+				if tt.sel.Match(project, name, e.status) {
+					got = append(got, project+"/"+name)
+				}
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("got %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestEnvSelectorValidate(t *testing.T) {
+	if err := (EnvSelector{Project: "prod-*", Name: "web-[0-9]"}).Validate(); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	if err := (EnvSelector{Name: "web-["}).Validate(); err == nil {
+		t.Error("expected an error for an invalid pattern")
+	}
+}
@@ -0,0 +1,181 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Variables : values interpolated on a definition as ${var.name}, the
+// environment is available as ${env.NAME}. Values are interpolated as
+// strings, a value made of a single reference can be given a type as
+// ${int:var.name}, ${float:var.name} or ${bool:var.name}
+type Variables map[string]string
+
+var variableRef = regexp.MustCompile(`\$?\$\{(?:(int|float|bool):)?(var|env)\.([A-Za-z0-9_\-]+)\}`)
+
+// LoadVariables : loads variables from yaml files and key=value pairs, later
+// values override earlier ones
+func LoadVariables(files []string, pairs []string) (Variables, error) {
+	vars := make(Variables)
+
+	for _, file := range files {
+		payload, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, errors.New("Can't access variables file " + file)
+		}
+		values := make(map[string]interface{})
+		if err := yaml.Unmarshal(payload, &values); err != nil {
+			return nil, errors.New("Variables file " + file + " is not a valid yaml file")
+		}
+		for k, v := range values {
+			switch v.(type) {
+			case map[interface{}]interface{}, []interface{}:
+				return nil, errors.New("Variable " + k + " on " + file + " should be a string, number or boolean")
+			}
+			vars[k] = fmt.Sprint(v)
+		}
+	}
+
+	for _, pair := range pairs {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, errors.New("Invalid variable '" + pair + "', it should be specified as key=value")
+		}
+		vars[parts[0]] = parts[1]
+	}
+
+	return vars, nil
+}
+
+// Interpolate : replaces all variable references on a string, returning the
+// references that could not be resolved. $${...} escapes a reference
+func (v Variables) Interpolate(s string) (string, []string) {
+	var undefined []string
+
+	res := variableRef.ReplaceAllStringFunc(s, func(ref string) string {
+		if strings.HasPrefix(ref, "$$") {
+			return ref[1:]
+		}
+
+		m := variableRef.FindStringSubmatch(ref)
+		value, ok := v.lookup(m[2], m[3])
+		if !ok {
+			undefined = append(undefined, m[2]+"."+m[3])
+		}
+		return value
+	})
+
+	return res, undefined
+}
+
+func (v Variables) lookup(kind, name string) (string, bool) {
+	if kind == "env" {
+		return os.LookupEnv(name)
+	}
+	value, ok := v[name]
+	return value, ok
+}
+
+// interpolateValue : interpolates a definition value as a string, unless
+// it is made of a single reference with an explicit type
+func (v Variables) interpolateValue(s string) (interface{}, []string, error) {
+	res, undefined := v.Interpolate(s)
+	if len(undefined) > 0 {
+		return res, undefined, nil
+	}
+
+	typ := ReferenceType(s)
+	if typ == "" {
+		return res, nil, nil
+	}
+	value, err := typedValue(typ, res)
+	return value, nil, err
+}
+
+// ReferenceType : gets the type given to a value made of a single
+// variable reference, empty if it has no explicit type
+func ReferenceType(s string) string {
+	loc := variableRef.FindStringSubmatchIndex(s)
+	if loc == nil || loc[0] != 0 || loc[1] != len(s) || loc[2] < 0 || strings.HasPrefix(s, "$$") {
+		return ""
+	}
+	return s[loc[2]:loc[3]]
+}
+
+// typedValue : converts an interpolated value to its explicit type
+func typedValue(typ, value string) (interface{}, error) {
+	var typed interface{}
+	var err error
+	switch typ {
+	case "int":
+		typed, err = strconv.Atoi(value)
+	case "float":
+		typed, err = strconv.ParseFloat(value, 64)
+	case "bool":
+		typed, err = strconv.ParseBool(value)
+	}
+	if err != nil {
+		return nil, errors.New("Variable value '" + value + "' is not a valid " + typ)
+	}
+	return typed, nil
+}
+
+func (v Variables) interpolateMapSlice(s yaml.MapSlice) (yaml.MapSlice, []string, error) {
+	var undefined []string
+	for i, item := range s {
+		value, u, err := v.interpolateAny(item.Value)
+		if err != nil {
+			return s, nil, err
+		}
+		s[i].Value = value
+		undefined = append(undefined, u...)
+	}
+	return s, undefined, nil
+}
+
+func (v Variables) interpolateAny(value interface{}) (interface{}, []string, error) {
+	switch t := value.(type) {
+	case string:
+		return v.interpolateValue(t)
+	case yaml.MapSlice:
+		return v.interpolateMapSlice(t)
+	case []interface{}:
+		var undefined []string
+		for i := range t {
+			item, u, err := v.interpolateAny(t[i])
+			if err != nil {
+				return t, nil, err
+			}
+			t[i] = item
+			undefined = append(undefined, u...)
+		}
+		return t, undefined, nil
+	}
+	return value, nil, nil
+}
+
+// UndefinedVariablesError : error listing all undefined references
+func UndefinedVariablesError(undefined []string) error {
+	seen := make(map[string]bool)
+	var names []string
+	for _, name := range undefined {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return errors.New("Undefined variables on definition: " + strings.Join(names, ", "))
+}
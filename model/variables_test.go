@@ -0,0 +1,151 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package model
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestVariablesInterpolate(t *testing.T) {
+	os.Setenv("ERNEST_TEST_REGION", "eu-west-1")
+	defer os.Unsetenv("ERNEST_TEST_REGION")
+
+	vars := Variables{"name": "web", "count": "3"}
+
+	tests := []struct {
+		in        string
+		want      string
+		undefined []string
+	}{
+		{"${var.name}", "web", nil},
+		{"${var.name}-${var.count}", "web-3", nil},
+		{"${env.ERNEST_TEST_REGION}", "eu-west-1", nil},
+		{"$${var.name}", "${var.name}", nil},
+		{"$${var.name}-${var.name}", "${var.name}-web", nil},
+		{"${int:var.count}", "3", nil},
+		{"${var.missing}", "", []string{"var.missing"}},
+		{"${env.ERNEST_TEST_MISSING}", "", []string{"env.ERNEST_TEST_MISSING"}},
+		{"plain", "plain", nil},
+	}
+
+	for _, tt := range tests {
+		got, undefined := vars.Interpolate(tt.in)
+		if got != tt.want || !reflect.DeepEqual(undefined, tt.undefined) {
+			t.Errorf("Interpolate(%q) = %q, %v, want %q, %v", tt.in, got, undefined, tt.want, tt.undefined)
+		}
+	}
+}
+
+func TestReferenceType(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"${int:var.x}", "int"},
+		{"${float:env.X}", "float"},
+		{"${bool:var.x}", "bool"},
+		{"${var.x}", ""},
+		{"$${int:var.x}", ""},
+		{"a${int:var.x}", ""},
+		{"${int:var.x}b", ""},
+	}
+
+	for _, tt := range tests {
+		if got := ReferenceType(tt.in); got != tt.want {
+			t.Errorf("ReferenceType(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestDefinitionInterpolate(t *testing.T) {
+	vars := Variables{
+		"version": "1.10",
+		"answer":  "no",
+		"mode":    "0755",
+		"year":    "2024",
+		"count":   "3",
+		"ratio":   "0.5",
+		"enabled": "true",
+		"word":    "x",
+	}
+
+	tests := []struct {
+		name string
+		in   string
+		want string
+		err  string
+	}{
+		{
+			name: "values are kept as strings",
+			in:   "name: app\nversion: ${var.version}\nanswer: ${var.answer}\nmode: ${var.mode}\n",
+			want: "name: app\nversion: \"1.10\"\nanswer: \"no\"\nmode: \"0755\"\n",
+		},
+		{
+			name: "typed references",
+			in:   "name: app\ncount: ${int:var.count}\nratio: ${float:var.ratio}\nenabled: ${bool:var.enabled}\n",
+			want: "name: app\ncount: 3\nratio: 0.5\nenabled: true\n",
+		},
+		{
+			name: "nested values",
+			in:   "name: app\nservers:\n- name: web-${var.count}\n  count: ${int:var.count}\n",
+			want: "name: app\nservers:\n- name: web-3\n  count: 3\n",
+		},
+		{
+			name: "escaped references",
+			in:   "name: app\nscript: echo $${var.count}\n",
+			want: "name: app\nscript: echo ${var.count}\n",
+		},
+		{
+			name: "numeric names stay strings",
+			in:   "name: ${var.year}\n",
+			want: "name: \"2024\"\n",
+		},
+		{
+			name: "invalid typed values fail",
+			in:   "name: app\ncount: ${int:var.word}\n",
+			err:  "Variable value 'x' is not a valid int",
+		},
+		{
+			name: "typed names fail",
+			in:   "name: ${int:var.year}\n",
+			err:  "Definition name should be a string",
+		},
+		{
+			name: "undefined variables fail",
+			in:   "name: ${var.a}\nproject: ${var.b}\n",
+			err:  "Undefined variables on definition: var.a, var.b",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d Definition
+			if err := d.Load([]byte(tt.in)); err != nil {
+				t.Fatal(err)
+			}
+
+			err := d.Interpolate(vars)
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Fatalf("expected error %q, got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := d.Save()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("got\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -64,6 +64,10 @@ func (cl *componentlist) add(c diff.Change) {
 
 // PrintDiff : prints the diff output from two compared builds
 func PrintDiff(c *diff.Changelog) {
+	render(c, func() { diffTable(c) })
+}
+
+func diffTable(c *diff.Changelog) {
 	list := componentlist{
 		keys:       make(sort.StringSlice, 0),
 		components: make(map[string]*component),
@@ -0,0 +1,33 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package view
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ernestio/ernest-cli/model"
+	"github.com/olekukonko/tablewriter"
+)
+
+// PrintBuildTags : Pretty print for the build tags of an environment
+func PrintBuildTags(tags []model.BuildTag) {
+	render(tags, func() { buildTagsTable(tags) })
+}
+
+func buildTagsTable(tags []model.BuildTag) {
+	if len(tags) == 0 {
+		fmt.Println("\nThere are no tags for this environment")
+		fmt.Println("")
+		return
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Tag", "Build ID", "Message", "User", "Created"})
+	for _, t := range tags {
+		table.Append([]string{t.Name, t.Build, t.Message, t.User, t.CreatedAt})
+	}
+	table.Render()
+}
@@ -18,6 +18,11 @@ func PrintValidation(v *models.Validation) {
 		return
 	}
 
+	render(v, func() { validationTable(v) })
+}
+
+func validationTable(v *models.Validation) {
+
 	passed, failed, total := v.Stats()
 
 	for i, profile := range v.Profiles {
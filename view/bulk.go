@@ -0,0 +1,46 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package view
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ernestio/ernest-cli/model"
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+)
+
+// PrintBulkResults : prints the outcome of an operation run on many
+// environments
+func PrintBulkResults(results []model.BulkResult) {
+	render(results, func() { bulkResultsTable(results) })
+}
+
+func bulkResultsTable(results []model.BulkResult) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Project", "Environment", "Result", "Message"})
+	table.SetAutoWrapText(false)
+
+	var ok, awaiting, failed int
+	for _, r := range results {
+		result := r.Result
+		switch r.Result {
+		case model.BulkOK:
+			ok++
+			result = color.GreenString(result)
+		case model.BulkAwaiting:
+			awaiting++
+			result = color.YellowString(result)
+		case model.BulkFailed:
+			failed++
+			result = color.RedString(result)
+		}
+		table.Append([]string{r.Project, r.Environment, result, r.Message})
+	}
+	table.Render()
+
+	fmt.Printf("\n%d environments: %d ok, %d awaiting resolution, %d failed\n", len(results), ok, awaiting, failed)
+}
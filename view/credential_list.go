@@ -0,0 +1,47 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package view
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/ernestio/ernest-cli/model"
+	"github.com/olekukonko/tablewriter"
+)
+
+// CredentialSet : credential set details shown by credentials list, its
+// values are never shown
+type CredentialSet struct {
+	Name     string `json:"name"`
+	Provider string `json:"provider"`
+}
+
+// PrintCredentialSets : Pretty print for the credential sets of a profile
+func PrintCredentialSets(sets map[string]*model.CredentialSet) {
+	list := []CredentialSet{}
+	for name, s := range sets {
+		list = append(list, CredentialSet{Name: name, Provider: s.Provider})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+
+	render(list, func() { credentialSetsTable(list) })
+}
+
+func credentialSetsTable(sets []CredentialSet) {
+	if len(sets) == 0 {
+		fmt.Println("\nThere are no credentials stored yet")
+		fmt.Println("")
+		return
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Name", "Provider"})
+	for _, s := range sets {
+		table.Append([]string{s.Name, s.Provider})
+	}
+	table.Render()
+}
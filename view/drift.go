@@ -0,0 +1,121 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package view
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ernestio/ernest-cli/model"
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+)
+
+// PrintDriftReport : prints the drift detected on many environments
+func PrintDriftReport(reports []model.DriftReport) {
+	render(reports, func() { driftTable(reports) })
+}
+
+func driftTable(reports []model.DriftReport) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Project", "Environment", "Status", "Drifted", "Components"})
+	table.SetAutoWrapText(false)
+
+	var drifted, failed int
+	for _, r := range reports {
+		result := color.GreenString("no")
+		detail := ""
+		switch {
+		case r.Error != "":
+			failed++
+			result = color.RedString("unknown")
+			detail = r.Error
+		case r.Drifted:
+			drifted++
+			result = color.YellowString("yes")
+			detail = strings.Join(r.Components, "\n")
+		}
+		table.Append([]string{r.Project, r.Environment, r.Status, result, detail})
+	}
+	table.SetRowLine(true)
+	table.Render()
+
+	fmt.Printf("\n%d environments: %d drifted, %d could not be checked\n", len(reports), drifted, failed)
+}
+
+type junitSuites struct {
+	XMLName xml.Name     `xml:"testsuites"`
+	Name    string       `xml:"name,attr"`
+	Suites  []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Errors   int         `xml:"errors,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// WriteDriftJUnit : writes the drift detected on many environments as a
+// JUnit XML report, with a test suite per project and a failing test case
+// per drifted environment
+func WriteDriftJUnit(w io.Writer, reports []model.DriftReport) error {
+	report := junitSuites{Name: "ernest drift"}
+	suites := make(map[string]int)
+
+	for _, r := range reports {
+		i, ok := suites[r.Project]
+		if !ok {
+			i = len(report.Suites)
+			suites[r.Project] = i
+			report.Suites = append(report.Suites, junitSuite{Name: r.Project})
+		}
+		suite := &report.Suites[i]
+
+		tc := junitCase{Name: r.Environment, Classname: r.Project}
+		switch {
+		case r.Error != "":
+			suite.Errors++
+			tc.Error = &junitMessage{Message: r.Error, Type: "error"}
+		case r.Drifted:
+			suite.Failures++
+			tc.Failure = &junitMessage{
+				Message: fmt.Sprintf("%d components drifted", len(r.Components)),
+				Type:    "drift",
+				Body:    strings.Join(r.Components, "\n"),
+			}
+		}
+		suite.Tests++
+		suite.Cases = append(suite.Cases, tc)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintln(w)
+	return err
+}
@@ -3,11 +3,16 @@ package view
 import (
 	"fmt"
 
+	h "github.com/ernestio/ernest-cli/helper"
 	"github.com/fatih/color"
 )
 
 // EnvDry : Pretty print for env Dry
 func EnvDry(lines []string) {
+	render(lines, func() { envDryTable(lines) })
+}
+
+func envDryTable(lines []string) {
 	if len(lines) == 0 {
 		fmt.Println("")
 		color.Green("This definition is up to date with latest changes. Nothing will be applied")
@@ -18,7 +23,7 @@ func EnvDry(lines []string) {
 	color.Green("Applying this definition will:")
 	fmt.Println("")
 	for i := range lines {
-		fmt.Println(" - " + lines[i])
+		fmt.Println(" - " + h.MaskSecrets(lines[i]))
 	}
 	fmt.Println("")
 	fmt.Println("If you're agree with these changes please rerun apply without --dry option")
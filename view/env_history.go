@@ -8,25 +8,43 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 
+	"github.com/ernestio/ernest-cli/model"
 	"github.com/olekukonko/tablewriter"
-
-	emodels "github.com/ernestio/ernest-go-sdk/models"
 )
 
-// PrintEnvHistory : Pretty print for build history
-func PrintEnvHistory(name string, builds []*emodels.Build) {
+// PrintEnvHistory : Pretty print for build history, with the number of
+// changed components of each build when requested
+func PrintEnvHistory(name string, builds []model.HistoryEntry, changes bool) {
+	render(builds, func() { envHistoryTable(name, builds, changes) })
+}
+
+func envHistoryTable(name string, builds []model.HistoryEntry, changes bool) {
 	if len(builds) == 0 {
 		fmt.Println("\nThere are no registered builds for this environment")
 		fmt.Println("")
 	} else {
 		table := tablewriter.NewWriter(os.Stdout)
-		table.SetHeader([]string{"ID", "Name", "Type", "Status", "Version", "User"})
-		num := len(builds) + 1
+		header := []string{"ID", "Name", "Type", "Status", "Created", "User", "Duration"}
+		if changes {
+			header = append(header, "Changes")
+		}
+		table.SetHeader(append(header, "Build ID", "Tags", "Message"))
 		for _, b := range builds {
-			num = num - 1
-			id := strconv.Itoa(num)
-			table.Append([]string{id, name, b.Type, b.Status, b.CreatedAt, b.Username})
+			duration := b.Duration
+			if duration == "" {
+				duration = "-"
+			}
+			row := []string{strconv.Itoa(b.Position), name, b.Type, b.Status, b.CreatedAt, b.User, duration}
+			if changes {
+				n := "-"
+				if b.Changes != nil {
+					n = strconv.Itoa(*b.Changes)
+				}
+				row = append(row, n)
+			}
+			table.Append(append(row, b.ID, strings.Join(b.Tags, ", "), b.Message))
 		}
 		table.Render()
 	}
@@ -14,8 +14,18 @@ import (
 	emodels "github.com/ernestio/ernest-go-sdk/models"
 )
 
+// EnvInfo : environment details shown by env info
+type EnvInfo struct {
+	Environment *emodels.Environment `json:"environment"`
+	Build       *emodels.Build       `json:"build"`
+}
+
 // PrintEnvInfo : Pretty print for build info
 func PrintEnvInfo(env *emodels.Environment, build *emodels.Build) {
+	render(EnvInfo{Environment: env, Build: build}, func() { envInfoTable(env, build) })
+}
+
+func envInfoTable(env *emodels.Environment, build *emodels.Build) {
 	fmt.Println("================\nPlatform Details\n================\n ")
 	parts := strings.Split(env.Name, "/")
 	fmt.Println("Name : " + parts[1])
@@ -15,6 +15,10 @@ import (
 
 // PrintEnvList : Pretty print for a build list
 func PrintEnvList(envs []*emodels.Environment) {
+	render(envs, func() { envListTable(envs) })
+}
+
+func envListTable(envs []*emodels.Environment) {
 	if len(envs) == 0 {
 		fmt.Println("\nThere are no environments created yet")
 		fmt.Println("")
@@ -0,0 +1,41 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package view
+
+import (
+	"fmt"
+	"time"
+)
+
+// Info : current target and session information
+type Info struct {
+	Profile string `json:"profile"`
+	Target  string `json:"target"`
+	User    string `json:"user"`
+	// Expires is the expiry of the session token, if it has one
+	Expires *time.Time `json:"token_expires_at,omitempty"`
+	Version string     `json:"version"`
+}
+
+// PrintInfo : Pretty print for the current target information
+func PrintInfo(info Info) {
+	render(info, func() {
+		fmt.Println("Profile:     " + info.Profile)
+		fmt.Println("Target:      " + info.Target)
+		fmt.Println("User:        " + info.User)
+		if info.Expires != nil {
+			fmt.Println("Session:     " + sessionExpiry(*info.Expires))
+		}
+		fmt.Println("CLI Version: " + info.Version)
+	})
+}
+
+func sessionExpiry(expires time.Time) string {
+	left := time.Until(expires).Round(time.Second)
+	if left <= 0 {
+		return "expired on " + expires.Format(time.RFC3339)
+	}
+	return fmt.Sprintf("expires %s (in %s)", expires.Format(time.RFC3339), left)
+}
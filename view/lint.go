@@ -0,0 +1,38 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package view
+
+import (
+	"fmt"
+
+	"github.com/ernestio/ernest-cli/model"
+	"github.com/fatih/color"
+)
+
+// PrintLintResult : prints the issues found linting a definition
+func PrintLintResult(r *model.LintResult) {
+	render(r, func() { lintTable(r) })
+}
+
+func lintTable(r *model.LintResult) {
+	for _, issue := range r.Issues {
+		if issue.Severity == model.LintError {
+			color.Red(issue.String())
+		} else {
+			color.Yellow(issue.String())
+		}
+	}
+
+	if len(r.Issues) > 0 {
+		fmt.Println("")
+	}
+
+	if r.Errors() == 0 {
+		color.Green("%s is a valid %s definition (%d warnings)", r.File, r.Provider, r.Warnings())
+		return
+	}
+
+	fmt.Printf("%s: %s, %d warnings\n", r.File, color.RedString("%d errors", r.Errors()), r.Warnings())
+}
@@ -16,6 +16,10 @@ import (
 
 // PrintLoggerList : pretty print for loggers list
 func PrintLoggerList(loggers []*emodels.Logger) {
+	render(loggers, func() { loggerListTable(loggers) })
+}
+
+func loggerListTable(loggers []*emodels.Logger) {
 	if len(loggers) == 0 {
 		fmt.Println("There are no loggers created yet.")
 		return
@@ -12,6 +12,10 @@ import (
 
 // PrintNotificationList : Pretty print for notification model
 func PrintNotificationList(notifications []*emodels.Notification) {
+	render(notifications, func() { notificationListTable(notifications) })
+}
+
+func notificationListTable(notifications []*emodels.Notification) {
 	if len(notifications) == 0 {
 		fmt.Println("\nThere are no notifications created yet")
 		fmt.Println("")
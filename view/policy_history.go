@@ -12,6 +12,10 @@ import (
 
 // PrintPolicyHistory : Pretty print for policy model
 func PrintPolicyHistory(documents []*emodels.PolicyDocument) {
+	render(documents, func() { policyHistoryTable(documents) })
+}
+
+func policyHistoryTable(documents []*emodels.PolicyDocument) {
 	if len(documents) == 0 {
 		fmt.Println("\nThere are no policies created yet")
 		fmt.Println("")
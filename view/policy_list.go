@@ -12,6 +12,10 @@ import (
 
 // PrintPolicyList : Pretty print for policy model
 func PrintPolicyList(policies []*emodels.Policy) {
+	render(policies, func() { policyListTable(policies) })
+}
+
+func policyListTable(policies []*emodels.Policy) {
 	if len(policies) == 0 {
 		fmt.Println("\nThere are no policies created yet")
 		fmt.Println("")
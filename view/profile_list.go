@@ -0,0 +1,53 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package view
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ernestio/ernest-cli/model"
+	"github.com/olekukonko/tablewriter"
+)
+
+// Profile : target profile details shown by target list
+type Profile struct {
+	Name    string `json:"name"`
+	URL     string `json:"url"`
+	User    string `json:"user"`
+	Secrets string `json:"secret_store"`
+	Current bool   `json:"current"`
+}
+
+// PrintProfileList : Pretty print for the configured target profiles
+func PrintProfileList(p *model.Profiles) {
+	active := p.Active()
+	profiles := []Profile{}
+	for _, name := range p.Names() {
+		c := p.Profiles[name]
+		profiles = append(profiles, Profile{Name: name, URL: c.URL, User: c.User, Secrets: c.Secrets(), Current: name == active})
+	}
+
+	render(profiles, func() { profileListTable(profiles) })
+}
+
+func profileListTable(profiles []Profile) {
+	if len(profiles) == 0 {
+		fmt.Println("\nThere are no targets configured yet")
+		fmt.Println("")
+		return
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"", "Name", "Target", "User", "Secrets"})
+	for _, p := range profiles {
+		current := ""
+		if p.Current {
+			current = "*"
+		}
+		table.Append([]string{current, p.Name, p.URL, p.User, p.Secrets})
+	}
+	table.Render()
+}
@@ -12,6 +12,10 @@ import (
 
 // PrintProjectInfo : Pretty print for a project
 func PrintProjectInfo(project *emodels.Project) {
+	render(project, func() { projectInfoTable(project) })
+}
+
+func projectInfoTable(project *emodels.Project) {
 	fmt.Println("Name: ", project.Name)
 	fmt.Println("Provider: ")
 	fmt.Println("  Type: ", project.Type)
@@ -17,6 +17,10 @@ import (
 
 // PrintProjectList : Pretty print for a project list
 func PrintProjectList(projects []*emodels.Project) {
+	render(projects, func() { projectListTable(projects) })
+}
+
+func projectListTable(projects []*emodels.Project) {
 	if len(projects) == 0 {
 		fmt.Println("There are no projects created yet.")
 		return
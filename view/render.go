@@ -0,0 +1,128 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package view
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"text/template"
+
+	h "github.com/ernestio/ernest-cli/helper"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Renderer : renders the data backing a view on a specific output format
+type Renderer interface {
+	Render(w io.Writer, data interface{}, table func()) error
+}
+
+// TableRenderer : renders views as human readable tables
+type TableRenderer struct{}
+
+// Render : prints the view using its table representation
+func (r TableRenderer) Render(w io.Writer, data interface{}, table func()) error {
+	table()
+	return nil
+}
+
+// JSONRenderer : renders views as indented json
+type JSONRenderer struct{}
+
+// Render : prints the view data as json
+func (r JSONRenderer) Render(w io.Writer, data interface{}, table func()) error {
+	body, err := json.MarshalIndent(normalize(data), "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(body))
+	return err
+}
+
+// YAMLRenderer : renders views as yaml
+type YAMLRenderer struct{}
+
+// Render : prints the view data as yaml, using the same field names as
+// the json output
+func (r YAMLRenderer) Render(w io.Writer, data interface{}, table func()) error {
+	body, err := json.Marshal(normalize(data))
+	if err != nil {
+		return err
+	}
+
+	var generic interface{}
+	if err = json.Unmarshal(body, &generic); err != nil {
+		return err
+	}
+
+	body, err = yaml.Marshal(generic)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(body)
+	return err
+}
+
+// TemplateRenderer : renders views through a user provided go template
+type TemplateRenderer struct {
+	tmpl *template.Template
+}
+
+// Render : executes the template against the view data
+func (r TemplateRenderer) Render(w io.Writer, data interface{}, table func()) error {
+	if err := r.tmpl.Execute(w, normalize(data)); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintln(w)
+	return err
+}
+
+var renderer Renderer = TableRenderer{}
+
+// SetOutput : selects the renderer used by every view. Supported formats
+// are table, json, yaml and template=<go template>
+func SetOutput(format string) error {
+	switch {
+	case format == "" || format == "table":
+		renderer = TableRenderer{}
+	case format == "json":
+		renderer = JSONRenderer{}
+	case format == "yaml":
+		renderer = YAMLRenderer{}
+	case strings.HasPrefix(format, "template="):
+		tmpl, err := template.New("output").Parse(strings.TrimPrefix(format, "template="))
+		if err != nil {
+			return errors.New("Invalid output template: " + err.Error())
+		}
+		renderer = TemplateRenderer{tmpl: tmpl}
+	default:
+		return errors.New("Unsupported output format '" + format + "', valid formats are table, json, yaml and template=<template>")
+	}
+
+	return nil
+}
+
+// IsTable : reports if views are being rendered as human readable tables
+func IsTable() bool {
+	_, ok := renderer.(TableRenderer)
+	return ok
+}
+
+func render(data interface{}, table func()) {
+	h.EvaluateError(renderer.Render(h.MaskWriter(os.Stdout), data, table))
+}
+
+// normalize avoids rendering empty lists as null
+func normalize(data interface{}) interface{} {
+	v := reflect.ValueOf(data)
+	if v.Kind() == reflect.Slice && v.IsNil() {
+		return reflect.MakeSlice(v.Type(), 0, 0).Interface()
+	}
+	return data
+}
@@ -10,6 +10,10 @@ import (
 
 // PrintScheduleList : ..
 func PrintScheduleList(list map[string]interface{}) {
+	render(list, func() { scheduleListTable(list) })
+}
+
+func scheduleListTable(list map[string]interface{}) {
 	fmt.Println("")
 	if len(list) == 0 {
 		fmt.Println("There are no schedules created for this environment")
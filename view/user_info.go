@@ -8,6 +8,10 @@ import (
 
 // PrintUserInfo : ...
 func PrintUserInfo(u *models.User) {
+	render(u, func() { userInfoTable(u) })
+}
+
+func userInfoTable(u *models.User) {
 	fmt.Println("Username: ", u.Username)
 	fmt.Println("Type:     ", u.Type)
 	fmt.Println("Projects:")
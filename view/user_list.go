@@ -11,6 +11,10 @@ import (
 
 // PrintUserList : ...
 func PrintUserList(users []*emodels.User) {
+	render(users, func() { userListTable(users) })
+}
+
+func userListTable(users []*emodels.User) {
 	w := new(tabwriter.Writer)
 	w.Init(os.Stdout, 0, 8, 0, '\t', 0)
 